@@ -0,0 +1,134 @@
+package di
+
+import (
+	"reflect"
+	"sort"
+)
+
+type Lifetime string
+
+const (
+	LifetimeSingleton Lifetime = "singleton"
+	LifetimeTransient Lifetime = "transient"
+)
+
+// BindingInfo describes a binding of the container.
+type BindingInfo struct {
+	Id           Id
+	Kind         string
+	Type         reflect.Type
+	Lifetime     Lifetime
+	Dependencies []Id
+	Built        bool
+	Eager        bool
+	Condition    string
+	Default      bool
+	Priority     int
+	Active       bool
+	Module       string
+	Source       Source
+}
+
+// Bindings returns the bindings of the container sorted by id, alternatives of an id in
+// the order they were bound.
+func (c *Container) Bindings() []BindingInfo {
+	c.mutex.Lock()
+	ids := make([]Id, 0, len(c.rules))
+	bindings := make(map[Id][]*binding, len(c.rules))
+
+	for id, alternatives := range c.rules {
+		ids = append(ids, id)
+		bindings[id] = alternatives
+	}
+	c.mutex.Unlock()
+
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+
+	var infos []BindingInfo
+
+	for _, id := range ids {
+		active := c.selectBinding(bindings[id])
+
+		for _, b := range bindings[id] {
+			infos = append(infos, c.describe(id, b, b == active))
+		}
+	}
+
+	c.generics.Range(func(key, _ any) bool {
+		if b := c.instantiated(key.(Id)); b != nil {
+			infos = append(infos, c.describe(key.(Id), b, true))
+		}
+
+		return true
+	})
+
+	sort.SliceStable(infos, func(i, j int) bool {
+		return infos[i].Id < infos[j].Id
+	})
+
+	return infos
+}
+
+func (c *Container) describe(id Id, b *binding, active bool) BindingInfo {
+	info := BindingInfo{
+		Id:           id,
+		Kind:         ruleKind(b.rule),
+		Type:         ruleType(b.rule),
+		Lifetime:     LifetimeTransient,
+		Dependencies: c.ruleDependencies(b.rule),
+		Eager:        b.eager,
+		Default:      b.fallback,
+		Priority:     b.priority,
+		Active:       active,
+		Module:       b.module,
+		Source:       b.source,
+	}
+
+	if b.condition != nil {
+		info.Condition = b.condition.String()
+	}
+
+	target := b
+
+	if _, isType := b.rule.(*typeRule); isType {
+		target = c.targetBinding(b)
+	}
+
+	if _, isValue := b.rule.(*valueRule); isValue || target != nil && isSingleton(target.rule) {
+		info.Lifetime = LifetimeSingleton
+		info.Built = isBuilt(target.rule)
+	}
+
+	return info
+}
+
+// targetBinding follows type rules to the binding that builds the value
+func (c *Container) targetBinding(b *binding) *binding {
+	seen := make(map[*binding]bool)
+
+	for b != nil && !seen[b] {
+		r, ok := b.rule.(*typeRule)
+
+		if !ok {
+			return b
+		}
+
+		seen[b] = true
+		b = c.getBinding(reflectTypeId(r.typeTo))
+	}
+
+	return nil
+}
+
+// isBuilt reports whether a singleton was built, a singleton being built is not
+func isBuilt(rule Rule) bool {
+	if _, ok := rule.(*valueRule); ok {
+		return true
+	}
+
+	_, _, built := builtInstance(rule)
+
+	return built
+}
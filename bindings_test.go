@@ -0,0 +1,62 @@
+package di
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBindings(t *testing.T) {
+	resetContainer()
+
+	BindType[ModuleRepository, ModuleRepositoryImpl]()
+	BindAuto[ModuleStore](Eager())
+	BindFactory(func(repository ModuleRepository) (*ModuleHandler, error) {
+		return &ModuleHandler{Repository: repository}, nil
+	})
+	BindValue("port", 8080)
+
+	Impl[ModuleRepository]()
+
+	bindings := GetContainer().Bindings()
+	byId := make(map[Id]BindingInfo)
+
+	for _, info := range bindings {
+		byId[info.Id] = info
+	}
+
+	if len(bindings) != 5 || bindings[0].Id > bindings[1].Id {
+		t.Fatal("Failed asserting sorted bindings", bindings)
+	}
+
+	repository := byId[TypeId[ModuleRepository]()]
+
+	if repository.Kind != "type" || repository.Type != Type[ModuleRepositoryImpl]() || repository.Lifetime != LifetimeSingleton || !repository.Built {
+		t.Error("Failed asserting type binding", repository)
+	}
+
+	if !reflect.DeepEqual(repository.Dependencies, []Id{TypeId[ModuleRepositoryImpl]()}) {
+		t.Error("Failed asserting type dependencies", repository.Dependencies)
+	}
+
+	impl := byId[TypeId[ModuleRepositoryImpl]()]
+
+	if impl.Kind != "auto" || !reflect.DeepEqual(impl.Dependencies, []Id{TypeId[ModuleStore]()}) || impl.Source.Line == 0 {
+		t.Error("Failed asserting auto binding", impl)
+	}
+
+	store := byId[TypeId[ModuleStore]()]
+
+	if !store.Eager || !store.Built {
+		t.Error("Failed asserting eager binding", store)
+	}
+
+	handler := byId[TypeId[ModuleHandler]()]
+
+	if handler.Kind != "factory" || handler.Lifetime != LifetimeTransient || handler.Built {
+		t.Error("Failed asserting factory binding", handler)
+	}
+
+	if value := byId[ValueId("port")]; value.Kind != "value" || value.Type != Type[int]() || !value.Built {
+		t.Error("Failed asserting value binding", value)
+	}
+}
@@ -0,0 +1,241 @@
+package di
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ProfilesEnv lists the active profiles, comma separated, when they are not set with
+// SetProfiles.
+const ProfilesEnv = "DI_PROFILES"
+
+// Condition selects between the alternative bindings of an id, it is evaluated each
+// time the id is resolved.
+type Condition interface {
+	Matches(c *Container) bool
+	String() string
+}
+
+type condition struct {
+	description string
+	matches     func(c *Container) bool
+	// key identifies the condition, bindings with equal keys replace each other
+	key any
+}
+
+func (cond *condition) Matches(c *Container) bool {
+	return cond.matches(c)
+}
+
+func (cond *condition) String() string {
+	return cond.description
+}
+
+// When makes a binding an alternative that is active when all conditions match, and
+// that is preferred to the binding without conditions.
+func When(conditions ...Condition) BindOption {
+	return bindOptionFunc(func(b *binding) {
+		if len(conditions) == 1 {
+			b.condition = conditions[0]
+			return
+		}
+
+		descriptions := make([]string, len(conditions))
+		var key any = allKey{}
+
+		for i, cond := range conditions {
+			descriptions[i] = cond.String()
+			key = allKey{key, conditionKey(cond)}
+		}
+
+		b.condition = &condition{strings.Join(descriptions, " && "), func(c *Container) bool {
+			for _, cond := range conditions {
+				if !cond.Matches(c) {
+					return false
+				}
+			}
+
+			return true
+		}, key}
+	})
+}
+
+func Profile(name string) Condition {
+	return &condition{fmt.Sprintf("profile %s", name), func(c *Container) bool {
+		for _, profile := range c.Profiles() {
+			if profile == name {
+				return true
+			}
+		}
+
+		return false
+	}, [2]string{"profile", name}}
+}
+
+func EnvSet(name string) Condition {
+	return &condition{fmt.Sprintf("env %s set", name), func(_ *Container) bool {
+		return os.Getenv(name) != ""
+	}, [2]string{"env set", name}}
+}
+
+func EnvEquals(name string, value string) Condition {
+	return &condition{fmt.Sprintf("env %s=%s", name, value), func(_ *Container) bool {
+		return os.Getenv(name) == value
+	}, [3]string{"env equals", name, value}}
+}
+
+// If is a custom condition, described by description in diagnostics. Predicates can't be
+// compared, so each If is a distinct condition.
+func If(description string, predicate func() bool) Condition {
+	cond := &condition{description: description, matches: func(_ *Container) bool {
+		return predicate()
+	}}
+	cond.key = cond
+
+	return cond
+}
+
+func Not(cond Condition) Condition {
+	return &condition{"not " + cond.String(), func(c *Container) bool {
+		return !cond.Matches(c)
+	}, notKey{conditionKey(cond)}}
+}
+
+type allKey struct {
+	previous any
+	last     any
+}
+
+type notKey struct {
+	key any
+}
+
+// conditionKey returns a comparable value identifying cond
+func conditionKey(cond Condition) any {
+	if cond == nil {
+		return nil
+	}
+
+	if builtin, ok := cond.(*condition); ok {
+		return builtin.key
+	}
+
+	// a comparable type may still hold values that can't be compared, e.g. a slice in
+	// an interface field
+	if reflect.ValueOf(cond).Comparable() {
+		return cond
+	}
+
+	// conditions that can't be compared are told apart by their description
+	return cond.String()
+}
+
+func (c *Container) SetProfiles(profiles ...string) {
+	c.profiles.Store(profiles)
+}
+
+// Profiles returns the active profiles, by default read from DI_PROFILES.
+func (c *Container) Profiles() []string {
+	if profiles, ok := c.profiles.Load().([]string); ok {
+		return profiles
+	}
+
+	var profiles []string
+
+	for _, profile := range strings.Split(os.Getenv(ProfilesEnv), ",") {
+		if profile = strings.TrimSpace(profile); profile != "" {
+			profiles = append(profiles, profile)
+		}
+	}
+
+	return profiles
+}
+
+type ValidationReport struct {
+	// Active holds the binding selected for each id, sorted by id
+	Active []BindingInfo
+}
+
+// Validate reports the active binding of each id, and returns an error for each dependency
+// that has no active binding.
+func (c *Container) Validate() (*ValidationReport, error) {
+	c.mutex.Lock()
+	ids := make([]Id, 0, len(c.rules))
+
+	for id := range c.rules {
+		ids = append(ids, id)
+	}
+	c.mutex.Unlock()
+
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+
+	report := &ValidationReport{}
+	var errs []error
+
+	for _, id := range ids {
+		alternatives := c.alternatives(id)
+		b := c.selectBinding(alternatives)
+
+		if b == nil {
+			continue
+		}
+
+		report.Active = append(report.Active, c.describe(id, b, true))
+
+		for _, dependency := range c.requiredDependencies(b.rule) {
+			if c.getBinding(dependency) != nil {
+				continue
+			}
+
+			if alternatives := c.alternatives(dependency); len(alternatives) > 0 {
+				errs = append(errs, fmt.Errorf("%s depends on %s, which has no active alternative among: %s",
+					id, dependency, describeAlternatives(alternatives)))
+				continue
+			}
+
+			errs = append(errs, fmt.Errorf("%s depends on %s, which is not bound", id, dependency))
+		}
+	}
+
+	return report, errors.Join(errs...)
+}
+
+// requiredDependencies are the dependencies that fail resolution when they are not bound,
+// struct fields without a binding being left empty
+func (c *Container) requiredDependencies(rule Rule) []Id {
+	if r, ok := rule.(*autoRule); ok {
+		var ids []Id
+
+		for _, id := range c.fieldDependencies(r.typeTo) {
+			if strings.HasPrefix(string(id), string(ValueId(""))) {
+				ids = append(ids, id)
+			}
+		}
+
+		return append(ids, injectMethodDependencies(r.typeTo)...)
+	}
+
+	return c.ruleDependencies(rule)
+}
+
+func describeAlternatives(alternatives []*binding) string {
+	descriptions := make([]string, len(alternatives))
+
+	for i, b := range alternatives {
+		condition := "always"
+
+		if b.condition != nil {
+			condition = b.condition.String()
+		}
+
+		descriptions[i] = fmt.Sprintf("%s (%s)", condition, b.source)
+	}
+
+	return strings.Join(descriptions, ", ")
+}
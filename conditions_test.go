@@ -0,0 +1,200 @@
+package di
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type ConditionalStore interface {
+	Name() string
+}
+
+type MemoryStore struct{}
+
+func (s *MemoryStore) Name() string {
+	return "memory"
+}
+
+type PostgresStore struct{}
+
+func (s *PostgresStore) Name() string {
+	return "postgres"
+}
+
+type ConditionalStoreUser struct{}
+
+func (u *ConditionalStoreUser) Inject(store ConditionalStore) {}
+
+// comparable type, but not its values holding a slice
+type RegionCondition struct {
+	regions any
+}
+
+func (r RegionCondition) Matches(_ *Container) bool {
+	return true
+}
+
+func (r RegionCondition) String() string {
+	return fmt.Sprintf("regions %v", r.regions)
+}
+
+func TestWhenProfile(t *testing.T) {
+	resetContainer()
+
+	BindType[ConditionalStore, MemoryStore]()
+	BindType[ConditionalStore, PostgresStore](When(Profile("prod")))
+
+	if store := Impl[ConditionalStore](); store.Name() != "memory" {
+		t.Error("Failed asserting unconditional alternative", store.Name())
+	}
+
+	GetContainer().SetProfiles("prod")
+
+	if store := Impl[ConditionalStore](); store.Name() != "postgres" {
+		t.Error("Failed asserting conditional alternative", store.Name())
+	}
+
+	bindings := Bindings()
+	var active, inactive int
+
+	for _, b := range bindings {
+		if b.Id == TypeId[ConditionalStore]() && b.Active && b.Condition == "profile prod" {
+			active++
+		}
+
+		if b.Id == TypeId[ConditionalStore]() && !b.Active && b.Condition == "" {
+			inactive++
+		}
+	}
+
+	if active != 1 || inactive != 1 {
+		t.Error("Failed asserting listed alternatives", bindings)
+	}
+}
+
+func TestProfilesEnv(t *testing.T) {
+	resetContainer()
+	t.Setenv(ProfilesEnv, "dev, test")
+
+	if profiles := GetContainer().Profiles(); len(profiles) != 2 || profiles[1] != "test" {
+		t.Error("Failed asserting profiles", profiles)
+	}
+
+	BindType[ConditionalStore, PostgresStore](When(Not(Profile("dev"))))
+	BindType[ConditionalStore, MemoryStore](When(Profile("dev")))
+
+	if store := Impl[ConditionalStore](); store.Name() != "memory" {
+		t.Error("Failed asserting profile from env", store.Name())
+	}
+}
+
+func TestWhenEnvAndPredicate(t *testing.T) {
+	resetContainer()
+
+	enabled := false
+	BindType[ConditionalStore, MemoryStore](When(EnvEquals("STORE", "memory"), If("enabled", func() bool { return enabled })))
+	BindType[ConditionalStore, PostgresStore](When(EnvSet("DATABASE_URL")))
+
+	if _, err := ResolveImpl[ConditionalStore](); err == nil {
+		t.Error("Failed asserting no active alternative")
+	}
+
+	t.Setenv("STORE", "memory")
+	t.Setenv("DATABASE_URL", "postgres://")
+
+	if store := Impl[ConditionalStore](); store.Name() != "postgres" {
+		t.Error("Failed asserting env alternative", store.Name())
+	}
+
+	enabled = true
+
+	if store := Impl[ConditionalStore](); store.Name() != "postgres" {
+		t.Error("The latest active alternative should win", store.Name())
+	}
+
+	t.Setenv("DATABASE_URL", "")
+
+	if store := Impl[ConditionalStore](); store.Name() != "memory" {
+		t.Error("Failed asserting predicate alternative", store.Name())
+	}
+}
+
+func TestWhenSameDescription(t *testing.T) {
+	resetContainer()
+
+	memory, postgres := false, false
+	BindType[ConditionalStore, MemoryStore](When(If("enabled", func() bool { return memory })))
+	BindType[ConditionalStore, PostgresStore](When(If("enabled", func() bool { return postgres })))
+	BindType[ConditionalStore, MemoryStore](When(Profile("dev"), Not(Profile("prod"))))
+	BindType[ConditionalStore, PostgresStore](When(Profile("dev"), Not(Profile("test"))))
+
+	if alternatives := GetContainer().alternatives(TypeId[ConditionalStore]()); len(alternatives) != 4 {
+		t.Fatal("Different conditions with the same description should be kept apart", len(alternatives))
+	}
+
+	memory = true
+
+	if store := Impl[ConditionalStore](); store.Name() != "memory" {
+		t.Error("Failed asserting predicate alternative", store.Name())
+	}
+
+	BindType[ConditionalStore, PostgresStore](When(Profile("dev"), Not(Profile("prod"))))
+
+	if alternatives := GetContainer().alternatives(TypeId[ConditionalStore]()); len(alternatives) != 4 {
+		t.Error("Equal conditions should replace each other", len(alternatives))
+	}
+}
+
+func TestWhenUncomparableCondition(t *testing.T) {
+	resetContainer()
+
+	BindType[ConditionalStore, MemoryStore](When(RegionCondition{[]string{"eu"}}))
+	BindType[ConditionalStore, PostgresStore](When(RegionCondition{[]string{"eu"}}))
+	BindType[ConditionalStore, MemoryStore](When(RegionCondition{[]string{"us"}}))
+
+	if alternatives := GetContainer().alternatives(TypeId[ConditionalStore]()); len(alternatives) != 2 {
+		t.Error("Conditions that can't be compared should be told apart by their description", len(alternatives))
+	}
+}
+
+func TestValidate(t *testing.T) {
+	resetContainer()
+
+	BindType[ConditionalStore, PostgresStore](When(Profile("prod")))
+	BindConstructor(func(store ConditionalStore) *ConditionalStoreUser {
+		return &ConditionalStoreUser{}
+	})
+	BindFactory(func(store *ModuleStore) (*ModuleHandler, error) {
+		return &ModuleHandler{}, nil
+	})
+	BindAuto[ModuleRepositoryImpl]()
+
+	_, err := GetContainer().Validate()
+
+	if err == nil ||
+		!strings.Contains(err.Error(), "di.ConditionalStoreUser depends on di.ConditionalStore, which has no active alternative among: profile prod (") ||
+		!strings.Contains(err.Error(), "di.ModuleHandler depends on di.ModuleStore, which is not bound") ||
+		!strings.Contains(err.Error(), "di.ModuleRepositoryImpl depends on di.ModuleStore, which is not bound") {
+		t.Error("Failed asserting validation errors", err)
+	}
+
+	GetContainer().SetProfiles("prod")
+	BindAuto[ModuleStore]()
+
+	report, err := GetContainer().Validate()
+
+	if err != nil {
+		t.Error(err)
+	}
+
+	for _, b := range report.Active {
+		if b.Id == TypeId[ConditionalStore]() && (b.Condition != "profile prod" || b.Type != Type[PostgresStore]()) {
+			t.Error("Failed asserting active alternative", b)
+		}
+	}
+
+	if len(report.Active) != 6 {
+		t.Error("Failed asserting report", report.Active)
+	}
+}
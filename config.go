@@ -0,0 +1,468 @@
+package di
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type ConfigLayer int
+
+const (
+	LayerDefaults ConfigLayer = iota
+	LayerFile
+	LayerEnv
+	LayerOverride
+)
+
+type ConfigSource interface {
+	Layer() ConfigLayer
+	Load(target reflect.Value) error
+}
+
+type ConfigError struct {
+	Errors []error
+}
+
+func (e *ConfigError) Error() string {
+	messages := make([]string, len(e.Errors))
+
+	for i, err := range e.Errors {
+		messages[i] = err.Error()
+	}
+
+	return "invalid configuration: " + strings.Join(messages, "; ")
+}
+
+// trackedSource is a source reporting the fields it sets, including the ones it sets to
+// their zero value, so that they satisfy `required`
+type trackedSource interface {
+	loadTracked(target reflect.Value, set func(field reflect.Value)) error
+}
+
+// fieldKey identifies a field by its address, the type telling apart a struct from its
+// first field
+type fieldKey struct {
+	typeInfo reflect.Type
+	address  uintptr
+}
+
+func keyOf(field reflect.Value) fieldKey {
+	return fieldKey{field.Type(), field.Addr().Pointer()}
+}
+
+type defaultsSource struct {
+	value reflect.Value
+}
+
+func Defaults[T any](value T) ConfigSource {
+	return &defaultsSource{reflect.ValueOf(value)}
+}
+
+func (s *defaultsSource) Layer() ConfigLayer {
+	return LayerDefaults
+}
+
+func (s *defaultsSource) Load(target reflect.Value) error {
+	return s.loadTracked(target, func(reflect.Value) {})
+}
+
+// loadTracked merges the non-zero fields of the defaults, keeping the defaults of the tags
+// for the others.
+func (s *defaultsSource) loadTracked(target reflect.Value, set func(field reflect.Value)) error {
+	if s.value.Type() != target.Type() {
+		return fmt.Errorf("defaults of type %s cannot be loaded into %s", s.value.Type(), target.Type())
+	}
+
+	mergeNonZero(target, s.value, set)
+
+	return nil
+}
+
+func mergeNonZero(target reflect.Value, value reflect.Value, set func(field reflect.Value)) {
+	for i := 0; i < value.NumField(); i++ {
+		field := target.Field(i)
+		fieldValue := value.Field(i)
+
+		if !value.Type().Field(i).IsExported() || fieldValue.IsZero() {
+			continue
+		}
+
+		if isEnvStruct(fieldValue.Type()) {
+			if fieldValue.Kind() == reflect.Pointer {
+				if field.IsNil() {
+					field.Set(reflect.New(field.Type().Elem()))
+				}
+
+				field = field.Elem()
+				fieldValue = fieldValue.Elem()
+			}
+
+			mergeNonZero(field, fieldValue, set)
+			continue
+		}
+
+		field.Set(fieldValue)
+		set(field)
+	}
+}
+
+type jsonFileSource struct {
+	path string
+}
+
+func JSONFile(path string) ConfigSource {
+	return &jsonFileSource{path}
+}
+
+func (s *jsonFileSource) Layer() ConfigLayer {
+	return LayerFile
+}
+
+func (s *jsonFileSource) Path() string {
+	return s.path
+}
+
+func (s *jsonFileSource) Load(target reflect.Value) error {
+	return s.loadTracked(target, func(reflect.Value) {})
+}
+
+func (s *jsonFileSource) loadTracked(target reflect.Value, set func(field reflect.Value)) error {
+	content, err := os.ReadFile(s.path)
+
+	if err != nil {
+		return err
+	}
+
+	// Only keys present in the file override the lower layers
+	if err := json.Unmarshal(content, target.Addr().Interface()); err != nil {
+		return fmt.Errorf("%s: %w", s.path, err)
+	}
+
+	markJSONFields(target, content, set)
+
+	return nil
+}
+
+// markJSONFields reports the fields of target whose keys are present in content, matched
+// like encoding/json does
+func markJSONFields(target reflect.Value, content json.RawMessage, set func(field reflect.Value)) {
+	var object map[string]json.RawMessage
+
+	if json.Unmarshal(content, &object) != nil {
+		return
+	}
+
+	typeInfo := target.Type()
+
+	for i := 0; i < typeInfo.NumField(); i++ {
+		typeField := typeInfo.Field(i)
+		field := target.Field(i)
+		name, _, _ := strings.Cut(typeField.Tag.Get("json"), ",")
+
+		if name == "-" {
+			continue
+		}
+
+		if typeField.Anonymous && name == "" && field.Kind() == reflect.Struct {
+			markJSONFields(field, content, set)
+			continue
+		}
+
+		if !typeField.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = typeField.Name
+		}
+
+		raw, found := lookupJSONKey(object, name)
+
+		if !found {
+			continue
+		}
+
+		set(field)
+
+		if field.Kind() == reflect.Pointer && !field.IsNil() {
+			field = field.Elem()
+		}
+
+		if field.Kind() == reflect.Struct {
+			markJSONFields(field, raw, set)
+		}
+	}
+}
+
+func lookupJSONKey(object map[string]json.RawMessage, name string) (json.RawMessage, bool) {
+	if raw, found := object[name]; found {
+		return raw, true
+	}
+
+	for key, raw := range object {
+		if strings.EqualFold(key, name) {
+			return raw, true
+		}
+	}
+
+	return nil, false
+}
+
+type dotEnvFileSource struct {
+	path   string
+	prefix string
+}
+
+func DotEnvFile(path string, prefix string) ConfigSource {
+	return &dotEnvFileSource{path, prefix}
+}
+
+func (s *dotEnvFileSource) Layer() ConfigLayer {
+	return LayerFile
+}
+
+func (s *dotEnvFileSource) Path() string {
+	return s.path
+}
+
+func (s *dotEnvFileSource) Load(target reflect.Value) error {
+	return s.loadTracked(target, func(reflect.Value) {})
+}
+
+func (s *dotEnvFileSource) loadTracked(target reflect.Value, set func(field reflect.Value)) error {
+	content, err := os.ReadFile(s.path)
+
+	if err != nil {
+		return err
+	}
+
+	values, err := parseDotEnv(content)
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", s.path, err)
+	}
+
+	return loadValues(target, s.prefix, func(name string) (string, bool) {
+		value, found := values[name]
+		return value, found
+	}, set)
+}
+
+type envSource struct {
+	prefix string
+}
+
+func EnvVars(prefix string) ConfigSource {
+	return &envSource{prefix}
+}
+
+func (s *envSource) Layer() ConfigLayer {
+	return LayerEnv
+}
+
+func (s *envSource) Load(target reflect.Value) error {
+	return s.loadTracked(target, func(reflect.Value) {})
+}
+
+func (s *envSource) loadTracked(target reflect.Value, set func(field reflect.Value)) error {
+	return loadValues(target, s.prefix, os.LookupEnv, set)
+}
+
+type overridesSource struct {
+	values map[string]string
+}
+
+// Overrides are keyed by the `env` tag names, without any prefix.
+func Overrides(values map[string]string) ConfigSource {
+	return &overridesSource{values}
+}
+
+func (s *overridesSource) Layer() ConfigLayer {
+	return LayerOverride
+}
+
+func (s *overridesSource) Load(target reflect.Value) error {
+	return s.loadTracked(target, func(reflect.Value) {})
+}
+
+func (s *overridesSource) loadTracked(target reflect.Value, set func(field reflect.Value)) error {
+	return loadValues(target, "", func(name string) (string, bool) {
+		value, found := s.values[name]
+		return value, found
+	}, set)
+}
+
+func loadValues(target reflect.Value, prefix string, lookup func(string) (string, bool), set func(field reflect.Value)) error {
+	errs := walkEnv(target, prefix, func(name string, _ envTag, field reflect.Value) error {
+		value, found := lookup(name)
+
+		if !found {
+			return nil
+		}
+
+		set(field)
+
+		return setEnvValue(name, value, field)
+	})
+
+	if len(errs) > 0 {
+		return &ConfigError{errs}
+	}
+
+	return nil
+}
+
+func parseDotEnv(content []byte) (map[string]string, error) {
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		line = strings.TrimPrefix(line, "export ")
+		name, value, found := strings.Cut(line, "=")
+		name = strings.TrimSpace(name)
+
+		if !found || name == "" {
+			return nil, fmt.Errorf("line %d: expected NAME=VALUE", lineNumber)
+		}
+
+		value = strings.TrimSpace(value)
+
+		if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+			unquoted, err := strconv.Unquote(value)
+
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+			}
+
+			value = unquoted
+		} else if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+			value = value[1 : len(value)-1]
+		}
+
+		values[name] = value
+	}
+
+	return values, scanner.Err()
+}
+
+func loadConfig(target reflect.Value, sources []ConfigSource) error {
+	var errs []error
+
+	// fields set by any layer, even to their zero value, satisfy `required`
+	set := make(map[fieldKey]bool)
+	markSet := func(field reflect.Value) {
+		set[keyOf(field)] = true
+	}
+
+	errs = append(errs, walkEnv(target, "", func(name string, tag envTag, field reflect.Value) error {
+		if !tag.hasDefault {
+			return nil
+		}
+
+		markSet(field)
+
+		return setEnvValue(name, tag.defaultValue, field)
+	})...)
+
+	sorted := make([]ConfigSource, len(sources))
+	copy(sorted, sources)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Layer() < sorted[j].Layer()
+	})
+
+	for _, source := range sorted {
+		err := loadSource(source, target, markSet)
+
+		if configErr, ok := err.(*ConfigError); ok {
+			errs = append(errs, configErr.Errors...)
+		} else if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	errs = append(errs, walkEnv(target, "", func(name string, tag envTag, field reflect.Value) error {
+		if tag.required && !set[keyOf(field)] {
+			return fmt.Errorf("%s is required", name)
+		}
+
+		return nil
+	})...)
+
+	if len(errs) > 0 {
+		return &ConfigError{errs}
+	}
+
+	return nil
+}
+
+// loadSource loads a source, telling the fields it set by comparing them when it is not
+// a trackedSource
+func loadSource(source ConfigSource, target reflect.Value, set func(field reflect.Value)) error {
+	if tracked, ok := source.(trackedSource); ok {
+		return tracked.loadTracked(target, set)
+	}
+
+	before := make(map[fieldKey]any)
+
+	walkEnv(target, "", func(_ string, _ envTag, field reflect.Value) error {
+		before[keyOf(field)] = field.Interface()
+		return nil
+	})
+
+	err := source.Load(target)
+
+	walkEnv(target, "", func(_ string, _ envTag, field reflect.Value) error {
+		if previous, found := before[keyOf(field)]; !found || !reflect.DeepEqual(previous, field.Interface()) {
+			set(field)
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+func LoadConfig[T any](sources ...ConfigSource) (*T, error) {
+	typeInfo := Type[T]()
+
+	if typeInfo.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%s must be a struct", typeInfo)
+	}
+
+	config := new(T)
+
+	if err := loadConfig(reflect.ValueOf(config).Elem(), sources); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+func BindConfig[T any](sources []ConfigSource, options ...BindOption) {
+	if Type[T]().Kind() != reflect.Struct {
+		panic(fmt.Sprintf("%s must be a struct", Type[T]()))
+	}
+
+	binderOf(options).Bind(
+		TypeId[T](),
+		&providerRule{factoryRule: factoryRule{func() (*T, error) {
+			return LoadConfig[T](sources...)
+		}}},
+		options...,
+	)
+}
@@ -0,0 +1,161 @@
+package di
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type FileDB struct {
+	Host     string `json:"host" env:"HOST,default=localhost"`
+	Port     int    `json:"port" env:"PORT,default=5432"`
+	User     string `json:"user" env:"USER,default=admin"`
+	Password string `json:"password" env:"PASSWORD,required"`
+}
+
+type FileConfig struct {
+	Name string `json:"name" env:"NAME,default=default"`
+	Mode string `json:"mode" env:"MODE"`
+	DB   FileDB `json:"db" env:"DB"`
+}
+
+func writeFile(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestLoadConfigLayers(t *testing.T) {
+	jsonPath := writeFile(t, "config.json", `{"name": "from-json", "mode": "json", "db": {"host": "json-host", "port": 1}}`)
+	envPath := writeFile(t, ".env", "# comment\nexport TEST_MODE=dotenv\nTEST_DB_PORT=2\nTEST_DB_PASSWORD=\"dot\\tenv\"\n")
+
+	t.Setenv("TEST_DB_PORT", "3")
+
+	config, err := LoadConfig[FileConfig](
+		Overrides(map[string]string{"DB_USER": "override"}),
+		EnvVars("TEST_"),
+		JSONFile(jsonPath),
+		DotEnvFile(envPath, "TEST_"),
+	)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if config.Name != "from-json" || config.Mode != "dotenv" {
+		t.Error("Files should override defaults", config)
+	}
+
+	if config.DB.Host != "json-host" || config.DB.Port != 3 || config.DB.User != "override" {
+		t.Error("Layers should be applied by precedence", config.DB)
+	}
+
+	if config.DB.Password != "dot\tenv" {
+		t.Error("Failed unquoting .env value", config.DB.Password)
+	}
+}
+
+func TestLoadConfigFails(t *testing.T) {
+	envPath := writeFile(t, ".env", "DB_PORT=port\n")
+
+	_, err := LoadConfig[FileConfig](DotEnvFile(envPath, ""), JSONFile("missing.json"))
+
+	var configErr *ConfigError
+
+	if !errors.As(err, &configErr) {
+		t.Fatal("Expected a ConfigError", err)
+	}
+
+	if len(configErr.Errors) != 3 {
+		t.Error("Every problem should be reported", err)
+	}
+
+	if !strings.Contains(err.Error(), "DB_PASSWORD is required") {
+		t.Error("Missing required error", err)
+	}
+}
+
+func TestLoadConfigDefaults(t *testing.T) {
+	config, err := LoadConfig[FileConfig](Defaults(FileConfig{Mode: "defaults", DB: FileDB{Password: "x"}}))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if config.Name != "default" || config.DB.Host != "localhost" || config.DB.Port != 5432 {
+		t.Error("Tag defaults should be kept for zero fields of Defaults", config)
+	}
+
+	if config.Mode != "defaults" || config.DB.Password != "x" {
+		t.Error("Failed asserting Defaults", config)
+	}
+}
+
+type RetryConfig struct {
+	Retries int  `json:"retries" env:"RETRIES,required"`
+	Verbose bool `json:"verbose" env:"VERBOSE,required"`
+}
+
+type customSource struct {
+	values map[string]any
+}
+
+func (s *customSource) Layer() ConfigLayer {
+	return LayerOverride
+}
+
+func (s *customSource) Load(target reflect.Value) error {
+	for name, value := range s.values {
+		target.FieldByName(name).Set(reflect.ValueOf(value))
+	}
+
+	return nil
+}
+
+func TestLoadConfigRequiredZero(t *testing.T) {
+	t.Setenv("RETRIES", "0")
+	jsonPath := writeFile(t, "config.json", `{"verbose": false}`)
+
+	config, err := LoadConfig[RetryConfig](EnvVars(""), JSONFile(jsonPath))
+
+	if err != nil || config.Retries != 0 || config.Verbose {
+		t.Error("Zero values set on purpose should satisfy required", config, err)
+	}
+
+	if _, err := LoadConfig[RetryConfig](Overrides(map[string]string{"RETRIES": "0"})); err == nil || !strings.Contains(err.Error(), "VERBOSE is required") || strings.Contains(err.Error(), "RETRIES") {
+		t.Error("Failed asserting required fields", err)
+	}
+
+	if _, err := LoadConfig[RetryConfig](&customSource{map[string]any{"Retries": 3, "Verbose": true}}); err != nil {
+		t.Error("Fields changed by custom sources should satisfy required", err)
+	}
+}
+
+func TestParseDotEnvFails(t *testing.T) {
+	if _, err := parseDotEnv([]byte("VALID=1\ninvalid\n")); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Error("Malformed lines should fail", err)
+	}
+}
+
+func TestBindConfig(t *testing.T) {
+	Reset()
+
+	BindConfig[FileDB]([]ConfigSource{Defaults(FileDB{Password: "default"}), Overrides(map[string]string{"HOST": "override"})})
+
+	config, err := Resolve[FileDB]()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if config.Host != "override" || config.Password != "default" || config != Instance[FileDB]() {
+		t.Error("Failed resolving bound config", config)
+	}
+}
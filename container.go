@@ -4,8 +4,15 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"log/slog"
 	"reflect"
+	"runtime"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
+	"unicode"
+	"unsafe"
 )
 
 const (
@@ -13,7 +20,7 @@ const (
 	LogLevelError   = 1
 	LogLevelWarning = 2
 	LogLevelNotice  = 4
-	LogLevelDefault = LogLevelError & LogLevelWarning & LogLevelNotice
+	LogLevelDefault = LogLevelError | LogLevelWarning | LogLevelNotice
 	LogLevelTrace   = 8
 	LogLevelAll     = 15
 )
@@ -24,10 +31,17 @@ type Rule interface {
 
 type typeRule struct {
 	typeTo reflect.Type
+	value  bool
 }
 
 func (r *typeRule) Resolve(c *Container) (reflect.Value, error) {
-	return c.ResolveType(r.typeTo)
+	v, err := c.ResolveType(r.typeTo)
+
+	if err != nil || !r.value || v.Kind() != reflect.Pointer || v.Type().Elem() != r.typeTo {
+		return v, err
+	}
+
+	return v.Elem(), nil
 }
 
 type instanceRule struct {
@@ -39,25 +53,15 @@ func (r *instanceRule) Resolve(_ *Container) (reflect.Value, error) {
 }
 
 type autoRule struct {
-	typeTo   reflect.Type
-	instance reflect.Value
+	typeTo reflect.Type
+	lazy   lazyValue
 }
 
 func (r *autoRule) Resolve(c *Container) (reflect.Value, error) {
-	var nv reflect.Value
-
-	if r.instance != nv {
-		return r.instance, nil
-	}
-
-	v, err := c.BuildType(r.typeTo)
-
-	if err != nil {
-		return reflect.Value{}, err
-	}
-
-	r.instance = v
-	return v, err
+	return r.lazy.get(c, func() (reflect.Value, func(), error) {
+		v, err := c.BuildType(r.typeTo)
+		return v, nil, err
+	})
 }
 
 type factoryRule struct {
@@ -71,7 +75,7 @@ func (r *factoryRule) Resolve(c *Container) (reflect.Value, error) {
 		return reflect.Value{}, err
 	}
 
-	returnValue, err := c.Call(r.callback)
+	returnValue, err := c.callSpan(r.callback)
 
 	if err != nil {
 		return reflect.Value{}, err
@@ -81,35 +85,290 @@ func (r *factoryRule) Resolve(c *Container) (reflect.Value, error) {
 		return reflect.Value{}, errors.New("callback must return one value and an error")
 	}
 
+	if !returnValue[1].IsNil() {
+		return reflect.Value{}, returnValue[1].Interface().(error)
+	}
+
 	return returnValue[0], nil
 }
 
 type providerRule struct {
 	factoryRule
-	instance *reflect.Value
+	lazy lazyValue
 }
 
 func (r *providerRule) Resolve(c *Container) (reflect.Value, error) {
-	if r.instance == nil {
+	return r.lazy.get(c, func() (reflect.Value, func(), error) {
 		instance, err := r.factoryRule.Resolve(c)
+		return instance, nil, err
+	})
+}
 
-		if err != nil {
+type constructorRule struct {
+	callback  any
+	singleton bool
+	lazy      lazyValue
+}
+
+func (r *constructorRule) Resolve(c *Container) (reflect.Value, error) {
+	if !r.singleton {
+		instance, _, err := r.construct(c)
+		return instance, err
+	}
+
+	return r.lazy.get(c, func() (reflect.Value, func(), error) {
+		return r.construct(c)
+	})
+}
+
+func (r *constructorRule) construct(c *Container) (reflect.Value, func(), error) {
+	returnValue, err := c.callSpan(r.callback)
+
+	if err != nil {
+		return reflect.Value{}, nil, err
+	}
+
+	if len(returnValue) > 1 {
+		errValue := returnValue[len(returnValue)-1]
+
+		if !errValue.IsNil() {
+			return reflect.Value{}, nil, errValue.Interface().(error)
+		}
+	}
+
+	var cleanup func()
+
+	if len(returnValue) == 3 && !returnValue[1].IsNil() {
+		// the cleanup also runs when the singleton is replaced with CloseReplaced
+		cleanup = sync.OnceFunc(returnValue[1].Interface().(func()))
+		c.addCleanup(cleanup)
+	}
+
+	instance := returnValue[0]
+
+	if instance.Kind() != reflect.Pointer && instance.Kind() != reflect.Interface {
+		ptr := reflect.New(instance.Type())
+		ptr.Elem().Set(instance)
+		instance = ptr
+	}
+
+	return instance, cleanup, nil
+}
+
+// lazyValue builds a singleton once. Its lock is not held while building, so that
+// resolutions waiting for each other report a circular dependency instead of deadlocking.
+type lazyValue struct {
+	mutex    sync.Mutex
+	instance *reflect.Value
+	cleanup  func()
+	builder  *resolution
+	done     chan struct{}
+}
+
+func (l *lazyValue) get(c *Container, build func() (reflect.Value, func(), error)) (reflect.Value, error) {
+	owner := c.owner()
+
+	l.mutex.Lock()
+
+	for l.instance == nil && l.builder != nil {
+		done := l.done
+		l.mutex.Unlock()
+
+		if err := c.waitFor(owner, l); err != nil {
 			return reflect.Value{}, err
 		}
 
-		r.instance = &instance
+		<-done
+		c.stopWaiting(owner)
+		l.mutex.Lock()
+	}
+
+	if l.instance != nil {
+		defer l.mutex.Unlock()
+		return *l.instance, nil
+	}
+
+	l.builder, l.done = owner, make(chan struct{})
+	l.mutex.Unlock()
+
+	instance, cleanup, err := build()
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if err == nil {
+		l.instance, l.cleanup = &instance, cleanup
+	}
+
+	close(l.done)
+	l.builder, l.done = nil, nil
+
+	return instance, err
+}
+
+func (l *lazyValue) built() (reflect.Value, func(), bool) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.instance == nil {
+		return reflect.Value{}, nil, false
+	}
+
+	return *l.instance, l.cleanup, true
+}
+
+func (l *lazyValue) currentBuilder() *resolution {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.builder
+}
+
+// resolution identifies a top-level resolution and the singleton it waits for
+type resolution struct {
+	waitingFor *lazyValue
+}
+
+func (c *Container) owner() *resolution {
+	if c.frame != nil && c.frame.owner != nil {
+		return c.frame.owner
+	}
+
+	return &resolution{}
+}
+
+// waitFor records that owner waits for value, unless the resolution building value
+// waits for owner, directly or through others
+func (c *Container) waitFor(owner *resolution, value *lazyValue) error {
+	c.waitMutex.Lock()
+	defer c.waitMutex.Unlock()
+
+	for next := value; next != nil; {
+		builder := next.currentBuilder()
+
+		if builder == nil {
+			break
+		}
+
+		if builder == owner {
+			id := Id("")
+
+			if c.frame != nil {
+				id = c.frame.id
+			}
+
+			return fmt.Errorf("circular dependency: %s is being built by a resolution that depends on it", id)
+		}
+
+		next = builder.waitingFor
+	}
+
+	owner.waitingFor = value
+
+	return nil
+}
+
+func (c *Container) stopWaiting(owner *resolution) {
+	c.waitMutex.Lock()
+	defer c.waitMutex.Unlock()
+
+	owner.waitingFor = nil
+}
+
+type valueRule struct {
+	value reflect.Value
+}
+
+func (r *valueRule) Resolve(_ *Container) (reflect.Value, error) {
+	return r.value, nil
+}
+
+type binding struct {
+	rule      Rule
+	eager     bool
+	module    string
+	exported  bool
+	override  bool
+	rebind    *unbindOptions
+	condition Condition
+	fallback  bool
+	priority  int
+	source    Source
+}
+
+// slot identifies the alternatives of an id that replace each other
+type slot struct {
+	fallback  bool
+	priority  int
+	condition any
+}
+
+func (b *binding) slot() slot {
+	return slot{b.fallback, b.priority, conditionKey(b.condition)}
+}
+
+// outranks reports whether b is preferred to other when both are active. Regular bindings
+// are preferred to defaults, then higher priorities, then bindings with conditions.
+func (b *binding) outranks(other *binding) bool {
+	if b.fallback != other.fallback {
+		return other.fallback
+	}
+
+	if b.priority != other.priority {
+		return b.priority > other.priority
+	}
+
+	return b.condition != nil && other.condition == nil
+}
+
+// site describes where a binding was set
+func (b *binding) site() string {
+	if b.module != "" {
+		return fmt.Sprintf("module %s (%s)", b.module, b.source)
 	}
 
-	return *r.instance, nil
+	return b.source.String()
+}
+
+// ruleStore holds the alternative bindings of each id
+type ruleStore map[Id][]*binding
+
+type containerState struct {
+	mutex          sync.Mutex
+	rules          ruleStore
+	cleanups       []func()
+	hooks          atomic.Value
+	logger         Logger
+	logLevel       int
+	report         atomic.Pointer[reportRecorder]
+	workers        int
+	stopTimeout    time.Duration
+	healthTimeout  time.Duration
+	modules        map[string]bool
+	enforceExports atomic.Bool
+	strictBinding  bool
+	frozen         atomic.Bool
+	profiles       atomic.Value
+	built          []reflect.Value
+	builtSet       sync.Map
+	captured       sync.Map
+	generics       sync.Map
+	waitMutex      sync.Mutex
+	injectPrivate  sync.Map
 }
 
-type ruleStore map[Id]Rule
+// resolveFrame links a resolution to the one that caused it
+type resolveFrame struct {
+	id     Id
+	span   *Span
+	parent *resolveFrame
+	owner  *resolution
+	source Source
+}
 
 type Container struct {
-	mutex    sync.Mutex
-	rules    ruleStore
-	logger   *log.Logger
-	logLevel int
+	*containerState
+	frame *resolveFrame
 }
 
 var currentContainer = NewContainer()
@@ -124,8 +383,11 @@ func GetContainer() *Container {
 
 func NewContainer() *Container {
 	return &Container{
-		rules:    make(ruleStore),
-		logLevel: LogLevelDefault,
+		containerState: &containerState{
+			rules:    make(ruleStore),
+			logLevel: LogLevelDefault,
+			modules:  make(map[string]bool),
+		},
 	}
 }
 
@@ -134,114 +396,488 @@ func resetContainer() {
 }
 
 func (c *Container) SetLogger(logger *log.Logger) {
+	if logger == nil {
+		c.logger = nil
+		return
+	}
+
+	c.logger = NewStdLogger(logger)
+}
+
+func (c *Container) SetStructuredLogger(logger Logger) {
 	c.logger = logger
 }
 
+func (c *Container) SetLogHandler(handler slog.Handler) {
+	c.logger = NewSlogLogger(handler)
+}
+
 func (c *Container) SetLogLevel(logLevel int) {
 	c.logLevel = logLevel
 }
 
-func (c *Container) SetRule(key Id, rule Rule) {
-	if c.logger != nil && hasLogLevel(c.logLevel, LogLevelTrace) {
-		c.logger.Printf("Setting %s as (%s): %+v", key, reflect.TypeOf(rule).String(), rule)
+func (c *Container) SetRule(key Id, rule Rule) error {
+	return c.bind(key, rule)
+}
+
+// SetStrictBinding makes rebinding an id without di.Override an error instead of a warning.
+func (c *Container) SetStrictBinding(strict bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.strictBinding = strict
+}
+
+func (c *Container) Bind(key Id, rule Rule, options ...BindOption) {
+	if err := c.bind(key, rule, options...); err != nil {
+		panic(err.Error())
+	}
+}
+
+func (c *Container) bind(key Id, rule Rule, options ...BindOption) error {
+	if c.IsFrozen() {
+		return fmt.Errorf("can't bind %s, the container is frozen", key)
+	}
+
+	b := &binding{rule: rule, source: callerSource()}
+
+	for _, option := range options {
+		option.applyBind(b)
+	}
+
+	existing, keepOverride, conflict, err := c.storeBinding(key, b)
+
+	if err != nil {
+		return err
+	}
+
+	if keepOverride {
+		c.log(LogLevelWarning, "keeping override", slog.String("id", string(key)), slog.Any("error", conflict))
+		return nil
+	}
+
+	if conflict != nil {
+		c.log(LogLevelWarning, "rebinding", slog.String("id", string(key)), slog.Any("error", conflict))
 	}
 
+	if c.logEnabled(LogLevelTrace) {
+		c.log(LogLevelTrace, "setting rule",
+			slog.String("id", string(key)),
+			slog.String("kind", ruleKind(rule)),
+			slog.String("rule", Redact(rule)),
+			slog.String("source", b.source.String()),
+		)
+	}
+
+	if existing != nil && b.rebind != nil {
+		if _, err := c.release(key, existing, *b.rebind); err != nil {
+			c.log(LogLevelError, "could not close replaced instance", slog.String("id", string(key)), slog.Any("error", err))
+		}
+	}
+
+	c.onBind(BindEvent{Id: key, Kind: ruleKind(rule), Rule: rule, Source: b.source})
+
+	return nil
+}
+
+// storeBinding stores b unless an override is kept, and returns the binding of the same
+// slot with the conflict to log. The conflict is checked and the binding stored at once,
+// so that concurrent binds of an id can't both miss each other.
+func (c *Container) storeBinding(key Id, b *binding) (existing *binding, keepOverride bool, conflict error, err error) {
+	bindingSlot := b.slot()
+
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
-	c.rules[key] = rule
+	if c.IsFrozen() {
+		return nil, false, nil, fmt.Errorf("can't bind %s, the container is frozen", key)
+	}
+
+	existing = findSlot(c.rules[key], bindingSlot)
+	keepOverride = existing != nil && existing.override && !b.override && b.rebind == nil
+
+	if keepOverride {
+		conflict = fmt.Errorf("%s is overridden by %s, ignoring the binding by %s", key, existing.site(), b.site())
+	} else if existing != nil && !b.override && b.rebind == nil {
+		conflict = fmt.Errorf("%s is bound by %s and rebound by %s, use di.Override to replace it", key, existing.site(), b.site())
+	}
+
+	if conflict != nil && c.strictBinding {
+		return nil, false, nil, conflict
+	}
+
+	if !keepOverride {
+		c.rules[key] = replaceSlot(c.rules[key], b)
+	}
+
+	return existing, keepOverride, conflict, nil
 }
 
 func (c *Container) HasRule(key Id) bool {
+	return c.getBinding(key) != nil || c.genericTemplate(key) != nil
+}
+
+func (c *Container) GetRule(key Id) Rule {
+	if b := c.getBinding(key); b != nil {
+		return b.rule
+	}
+
+	return nil
+}
+
+// getBinding returns the active alternative of key
+func (c *Container) getBinding(key Id) *binding {
+	if b := c.selectBinding(c.alternatives(key)); b != nil {
+		return b
+	}
+
+	return c.instantiated(key)
+}
+
+func (c *Container) alternatives(key Id) []*binding {
+	// rules don't change once frozen
+	if c.IsFrozen() {
+		return c.rules[key]
+	}
+
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
-	_, exists := c.rules[key]
+	return c.rules[key]
+}
+
+func (c *Container) selectBinding(alternatives []*binding) *binding {
+	var selected *binding
+
+	for _, b := range alternatives {
+		if b.condition != nil && !b.condition.Matches(c) {
+			continue
+		}
+
+		// the latest binding wins between equals
+		if selected == nil || !selected.outranks(b) {
+			selected = b
+		}
+	}
 
-	return exists
+	return selected
 }
 
-func (c *Container) GetRule(key Id) Rule {
+func findSlot(alternatives []*binding, key slot) *binding {
+	for _, b := range alternatives {
+		if b.slot() == key {
+			return b
+		}
+	}
+
+	return nil
+}
+
+// replaceSlot returns a copy of alternatives with b, as they are read without locking
+func replaceSlot(alternatives []*binding, b *binding) []*binding {
+	replaced := make([]*binding, 0, len(alternatives)+1)
+
+	for _, alternative := range alternatives {
+		if alternative.slot() != b.slot() {
+			replaced = append(replaced, alternative)
+		}
+	}
+
+	return append(replaced, b)
+}
+
+func (c *Container) addCleanup(cleanup func()) {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
-	value, _ := c.rules[key]
+	c.cleanups = append(c.cleanups, cleanup)
+}
 
-	return value
+func (c *Container) Close() {
+	c.mutex.Lock()
+	cleanups := c.cleanups
+	c.cleanups = nil
+	c.mutex.Unlock()
+
+	start := time.Now()
+
+	for i := len(cleanups) - 1; i >= 0; i-- {
+		c.log(LogLevelTrace, "running cleanup", slog.Int("index", i))
+
+		cleanups[i]()
+	}
+
+	c.onClose(CloseEvent{Cleanups: len(cleanups), Duration: time.Since(start)})
 }
 
-func (c *Container) ResolveType(typeInfo reflect.Type) (reflect.Value, error) {
-	if c.logger != nil && hasLogLevel(c.logLevel, LogLevelTrace) {
-		c.logger.Printf("Resolving %s", typeInfo.String())
+func (c *Container) withFrame(id Id, source Source) *Container {
+	frame := &resolveFrame{id: id, parent: c.frame, owner: c.owner(), source: source}
+
+	if c.frame != nil {
+		frame.span = c.frame.span
 	}
 
+	return &Container{c.containerState, frame}
+}
+
+func (c *Container) ResolveType(typeInfo reflect.Type) (reflect.Value, error) {
+	c.log(LogLevelTrace, "resolving", slog.String("type", typeInfo.String()))
+
 	if typeInfo.Kind() == reflect.Pointer {
 		typeInfo = typeInfo.Elem()
 	}
 
-	typeId := Id(typeInfo.String())
+	return c.resolve(typeKey(typeInfo), typeInfo)
+}
+
+func (c *Container) resolve(typeId Id, typeInfo reflect.Type) (reflect.Value, error) {
+	for frame := c.frame; frame != nil; frame = frame.parent {
+		if frame.id == typeId {
+			err := fmt.Errorf("circular dependency: %s", c.dependencyPath(typeId))
+			c.log(LogLevelError, "circular dependency", slog.String("type", string(typeId)), slog.Any("error", err))
+			return zeroValue(typeInfo), err
+		}
+	}
+
+	c.beforeResolve(ResolveEvent{Id: typeId, Type: typeInfo})
+
+	b := c.getBinding(typeId)
+
+	if b == nil {
+		b = c.instantiate(typeId, typeInfo)
+	}
+
+	if b == nil {
+		err := fmt.Errorf("rule %s not found", typeId)
 
-	if !c.HasRule(typeId) {
-		err := fmt.Errorf("rule %s not found", typeInfo.String())
-		if c.logger != nil && hasLogLevel(c.logLevel, LogLevelTrace) {
-			c.logger.Print(err)
+		if c.frame != nil && c.frame.id != "" {
+			err = fmt.Errorf("rule %s not found, required by %s (bound at %s)", typeId, c.frame.id, c.frame.source)
 		}
-		return reflect.Zero(typeInfo), err
+
+		c.log(LogLevelTrace, "rule not found", slog.String("type", string(typeId)), slog.Any("error", err))
+		c.afterResolve(ResolveEvent{Id: typeId, Type: typeInfo, Kind: ruleKind(nil), Err: err})
+		return zeroValue(typeInfo), err
+	}
+
+	if err := c.checkExported(typeId, b); err != nil {
+		c.log(LogLevelTrace, "not exported", slog.String("type", string(typeId)), slog.Any("error", err))
+		c.afterResolve(ResolveEvent{Id: typeId, Type: typeInfo, Kind: ruleKind(b.rule), Err: err})
+		return zeroValue(typeInfo), err
 	}
 
-	return c.GetRule(typeId).Resolve(c)
+	rule := b.rule
+	start := time.Now()
+	value, err := rule.Resolve(c.withFrame(typeId, b.source))
+
+	c.afterResolve(ResolveEvent{
+		Id:       typeId,
+		Type:     typeInfo,
+		Kind:     ruleKind(rule),
+		Duration: time.Since(start),
+		Err:      err,
+	})
+
+	if err != nil {
+		c.log(LogLevelTrace, "could not resolve",
+			slog.String("type", string(typeId)),
+			slog.String("kind", ruleKind(rule)),
+			slog.Duration("duration", time.Since(start)),
+			slog.String("source", b.source.String()),
+			slog.Any("error", err),
+		)
+
+		return value, fmt.Errorf("%s (bound at %s): %w", typeId, b.source, err)
+	}
+
+	c.log(LogLevelTrace, "resolved",
+		slog.String("type", string(typeId)),
+		slog.String("kind", ruleKind(rule)),
+		slog.Duration("duration", time.Since(start)),
+		slog.String("source", b.source.String()),
+	)
+
+	if isSingleton(rule) {
+		c.track(value)
+	}
+
+	c.recordCapture(typeId, b)
+
+	return value, nil
 }
 
-func (c *Container) BuildType(typeInfo reflect.Type) (reflect.Value, error) {
-	if c.logger != nil && hasLogLevel(c.logLevel, LogLevelTrace) {
-		c.logger.Printf("Building %s", typeInfo)
+type builtKey struct {
+	typeInfo reflect.Type
+	pointer  uintptr
+}
+
+// track records singletons once they are resolved, so dependencies are recorded
+// before their dependants.
+func (c *Container) track(value reflect.Value) {
+	if value.Kind() == reflect.Interface && !value.IsNil() {
+		value = value.Elem()
 	}
 
+	if value.Kind() != reflect.Pointer || value.IsNil() {
+		return
+	}
+
+	// zero sized values share their address, so the type is part of the key
+	key := builtKey{value.Type(), value.Pointer()}
+
+	if _, exists := c.builtSet.Load(key); exists {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if _, exists := c.builtSet.LoadOrStore(key, true); exists {
+		return
+	}
+
+	c.built = append(c.built, value)
+}
+
+func (c *Container) builtInstances() []reflect.Value {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	built := make([]reflect.Value, len(c.built))
+	copy(built, c.built)
+
+	return built
+}
+
+func isSingleton(rule Rule) bool {
+	switch r := rule.(type) {
+	case *instanceRule, *autoRule, *providerRule:
+		return true
+	case *constructorRule:
+		return r.singleton
+	}
+
+	return false
+}
+
+func zeroValue(typeInfo reflect.Type) reflect.Value {
+	if typeInfo == nil {
+		return reflect.Value{}
+	}
+
+	return reflect.Zero(typeInfo)
+}
+
+func (c *Container) dependencyPath(last Id) string {
+	path := []string{string(last)}
+
+	for frame := c.frame; frame != nil; frame = frame.parent {
+		path = append([]string{string(frame.id)}, path...)
+	}
+
+	return strings.Join(path, " -> ")
+}
+
+func (c *Container) BuildType(typeInfo reflect.Type) (reflect.Value, error) {
+	c.log(LogLevelTrace, "building", slog.String("type", typeInfo.String()))
+
+	c, span := c.startSpan(SpanBuild, typeInfo.String())
+	defer c.endSpan(span)
+
+	start := time.Now()
 	structPtr := reflect.New(typeInfo)
 
 	if typeInfo.Kind() != reflect.Struct {
-		if c.logger != nil && hasLogLevel(c.logLevel, LogLevelTrace) {
-			c.logger.Printf("%s is not a struct, returning without resolving children", typeInfo.String())
-		}
+		c.log(LogLevelTrace, "not a struct, returning without resolving children", slog.String("type", typeInfo.String()))
+		c.onBuild(BuildEvent{Type: typeInfo, Duration: time.Since(start)})
 
 		return structPtr, nil
 	}
 
+	if err := c.injectFields(structPtr); err != nil {
+		c.onBuild(BuildEvent{Type: typeInfo, Duration: time.Since(start), Err: err})
+		return reflect.Zero(typeInfo), err
+	}
+
+	c.onBuild(BuildEvent{Type: typeInfo, Duration: time.Since(start)})
+
+	return structPtr, nil
+}
+
+func (c *Container) InjectInto(ptr any) error {
+	structPtr := reflect.ValueOf(ptr)
+
+	if structPtr.Kind() != reflect.Pointer || structPtr.IsNil() || structPtr.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("InjectInto expects a non-nil pointer to a struct, got %T", ptr)
+	}
+
+	c.log(LogLevelTrace, "injecting into", slog.String("type", structPtr.Type().String()))
+
+	return c.injectFields(structPtr)
+}
+
+func (c *Container) injectFields(structPtr reflect.Value) error {
+	typeInfo := structPtr.Type().Elem()
 	structElem := structPtr.Elem()
 
 	for i := 0; i < typeInfo.NumField(); i++ {
 		typeField := typeInfo.Field(i)
 		structField := structElem.Field(i)
-		inject, err := c.shouldInject(typeField)
+		tag, err := c.shouldInject(typeField)
 
 		if err != nil {
-			if c.logger != nil && hasLogLevel(c.logLevel, LogLevelError) {
-				c.logger.Printf("ERROR: %s", err)
-			}
+			c.log(LogLevelError, "invalid tag",
+				slog.String("type", typeInfo.String()),
+				slog.String("field", typeField.Name),
+				slog.Any("error", err),
+			)
 
-			return reflect.Zero(typeInfo), err
+			return err
 		}
 
-		if !inject {
-			if c.logger != nil && hasLogLevel(c.logLevel, LogLevelTrace) {
-				c.logger.Printf("Tagged as @none, skipping %s", typeField.Name)
-			}
+		if !tag.inject {
+			c.log(LogLevelTrace, "not injected, skipping",
+				slog.String("type", typeInfo.String()),
+				slog.String("field", typeField.Name),
+			)
 
 			continue
 		}
 
-		if c.logger != nil && hasLogLevel(c.logLevel, LogLevelTrace) {
-			c.logger.Printf("Resolving child %s", typeField.Name)
+		if tag.value != "" {
+			structField, canSet := c.settableField(typeInfo, typeField, structField, tag)
+
+			if !canSet {
+				continue
+			}
+
+			if err := c.injectValue(typeField, structField, tag.value); err != nil {
+				c.log(LogLevelError, "could not inject value",
+					slog.String("type", typeInfo.String()),
+					slog.String("field", typeField.Name),
+					slog.Any("error", err),
+				)
+
+				return err
+			}
+
+			continue
 		}
 
+		c.log(LogLevelTrace, "resolving child",
+			slog.String("type", typeInfo.String()),
+			slog.String("field", typeField.Name),
+		)
+
 		childType := typeField.Type
 		isInterface := childType.Kind() == reflect.Interface
 		isPointer := childType.Kind() == reflect.Pointer
 
-		if isInterface && !c.HasRule(Id(childType.String())) {
-			if c.logger != nil && hasLogLevel(c.logLevel, LogLevelTrace) {
-				c.logger.Printf("%s is an interface but has no rule set, skipping", childType.String())
-			}
+		if isInterface && !c.HasRule(typeKey(childType)) {
+			c.log(LogLevelTrace, "interface has no rule set, skipping",
+				slog.String("type", childType.String()),
+				slog.String("field", typeField.Name),
+			)
+
 			continue
 		}
 
@@ -249,27 +885,26 @@ func (c *Container) BuildType(typeInfo reflect.Type) (reflect.Value, error) {
 			childType = childType.Elem()
 		}
 
-		if !c.HasRule(Id(childType.String())) {
+		if !c.HasRule(typeKey(childType)) {
 			continue
 		}
 
-		if !structField.CanSet() {
-			if c.logger != nil && hasLogLevel(c.logLevel, LogLevelWarning) {
-				c.logger.Printf("WARNING: Can't set private member `%s` of `%s`. You need to make this member public to "+
-					"inject it or add the tag `inject:\"@none\"` to mark that the field is skipped", typeField.Name, typeInfo.String())
-			}
+		structField, canSet := c.settableField(typeInfo, typeField, structField, tag)
 
+		if !canSet {
 			continue
 		}
 
 		builtChild, err := c.ResolveType(childType)
 
 		if err != nil {
-			if c.logger != nil && hasLogLevel(c.logLevel, LogLevelTrace) {
-				c.logger.Printf("ERROR: %s", err)
-			}
+			c.log(LogLevelTrace, "could not resolve child",
+				slog.String("type", typeInfo.String()),
+				slog.String("field", typeField.Name),
+				slog.Any("error", err),
+			)
 
-			return reflect.Zero(typeInfo), err
+			return err
 		}
 
 		var elem reflect.Value
@@ -280,22 +915,169 @@ func (c *Container) BuildType(typeInfo reflect.Type) (reflect.Value, error) {
 			elem = builtChild.Elem()
 		}
 
-		if c.logger != nil && hasLogLevel(c.logLevel, LogLevelTrace) {
-			c.logger.Printf("Setting child %#v", elem.Interface())
+		if c.logEnabled(LogLevelTrace) {
+			c.log(LogLevelTrace, "setting child",
+				slog.String("type", typeInfo.String()),
+				slog.String("field", typeField.Name),
+				slog.String("value", elem.Type().String()+Redact(elem.Interface())),
+			)
 		}
 
 		structField.Set(elem)
 	}
 
+	if err := c.callInjectMethods(structPtr); err != nil {
+		return err
+	}
+
 	if structPtr.Type().Implements(Type[Initializable]()) {
-		if c.logger != nil && hasLogLevel(c.logLevel, LogLevelTrace) {
-			c.logger.Printf("Initializing %s", structPtr.Type().String())
+		_, span := c.startSpan(SpanInitialize, structPtr.Type().Elem().String())
+		start := time.Now()
+		structPtr.MethodByName("Initialize").Call([]reflect.Value{})
+		c.endSpan(span)
+
+		duration := time.Since(start)
+
+		c.log(LogLevelTrace, "initialized",
+			slog.String("type", structPtr.Type().String()),
+			slog.Duration("duration", duration),
+		)
+		c.onInitialize(InitializeEvent{Type: structPtr.Type(), Duration: duration})
+	}
+
+	return nil
+}
+
+func (c *Container) callInjectMethods(structPtr reflect.Value) error {
+	ptrType := structPtr.Type()
+
+	for i := 0; i < ptrType.NumMethod(); i++ {
+		method := ptrType.Method(i)
+
+		if !isInjectMethod(method.Name) {
+			continue
 		}
 
-		structPtr.MethodByName("Initialize").Call([]reflect.Value{})
+		c.log(LogLevelTrace, "calling inject method",
+			slog.String("type", ptrType.String()),
+			slog.String("method", method.Name),
+		)
+
+		returnValue, err := c.Call(structPtr.Method(i).Interface())
+
+		if err != nil {
+			return fmt.Errorf("could not call %s.%s: %s", ptrType, method.Name, err)
+		}
+
+		if len(returnValue) == 0 {
+			continue
+		}
+
+		last := returnValue[len(returnValue)-1]
+
+		if last.Type() == Type[error]() && !last.IsNil() {
+			return fmt.Errorf("%s.%s failed: %w", ptrType, method.Name, last.Interface().(error))
+		}
 	}
 
-	return structPtr, nil
+	return nil
+}
+
+func isInjectMethod(name string) bool {
+	if name == "Inject" {
+		return true
+	}
+
+	// InjectXxx methods, but not e.g. Injector()
+	suffix := strings.TrimPrefix(name, "Inject")
+
+	return suffix != name && unicode.IsUpper([]rune(suffix)[0])
+}
+
+func (c *Container) settableField(typeInfo reflect.Type, typeField reflect.StructField, structField reflect.Value,
+	tag injectTag) (reflect.Value, bool) {
+	if structField.CanSet() {
+		return structField, true
+	}
+
+	if tag.private && c.isPrivateInjectionAllowed(typeInfo) {
+		c.log(LogLevelTrace, "tagged as private, setting unexported field",
+			slog.String("type", typeInfo.String()),
+			slog.String("field", typeField.Name),
+		)
+
+		return reflect.NewAt(structField.Type(), unsafe.Pointer(structField.UnsafeAddr())).Elem(), true
+	}
+
+	c.log(LogLevelWarning, "can't set private member. You need to make this member public to inject it, "+
+		"receive it through an `Inject` method or add the tag `inject:\"@none\"` to mark that the field is skipped",
+		slog.String("type", typeInfo.String()),
+		slog.String("field", typeField.Name),
+	)
+
+	return structField, false
+}
+
+func (c *Container) injectValue(typeField reflect.StructField, structField reflect.Value, name string) error {
+	key := ValueId(name)
+
+	if !c.HasRule(key) {
+		return fmt.Errorf("value %s for member %s not found", name, typeField.Name)
+	}
+
+	value, err := c.GetRule(key).Resolve(c)
+
+	if err != nil {
+		return err
+	}
+
+	converted, err := convertValue(value, structField.Type())
+
+	if err != nil {
+		return fmt.Errorf("could not inject value %s into member %s: %w", name, typeField.Name, err)
+	}
+
+	c.log(LogLevelTrace, "setting value",
+		slog.String("name", name),
+		slog.String("field", typeField.Name),
+	)
+
+	structField.Set(converted)
+
+	return nil
+}
+
+func (c *Container) allowPrivateInjection(pkgPath string) {
+	c.injectPrivate.Store(pkgPath, true)
+}
+
+// callerPackage returns the import path of the package calling the caller of callerPackage
+func callerPackage() string {
+	pc, _, _, ok := runtime.Caller(2)
+
+	if !ok {
+		return ""
+	}
+
+	return funcPackage(runtime.FuncForPC(pc).Name())
+}
+
+// funcPackage returns the import path of the package of a function name, e.g.
+// github.com/org/pkg.(*T).Method or github.com/org/pkg.init.0
+func funcPackage(name string) string {
+	slash := strings.LastIndexByte(name, '/')
+
+	if dot := strings.IndexByte(name[slash+1:], '.'); dot >= 0 {
+		name = name[:slash+1+dot]
+	}
+
+	// the linker escapes the dots of the last path element, e.g. gopkg.in/yaml%2ev3
+	return strings.ReplaceAll(name, "%2e", ".")
+}
+
+func (c *Container) isPrivateInjectionAllowed(t reflect.Type) bool {
+	_, allowed := c.injectPrivate.Load(t.PkgPath())
+	return allowed
 }
 
 func isStruct(t reflect.Type) bool {
@@ -311,34 +1093,46 @@ func isStructOrInterface(t reflect.Type) bool {
 	return isStruct(t) || isInterface(t)
 }
 
-func (c *Container) shouldInject(field reflect.StructField) (bool, error) {
-	injectTag := field.Tag.Get("inject")
-	t := field.Type
+type injectTag struct {
+	inject  bool
+	private bool
+	value   string
+}
 
-	var inject bool
+func (c *Container) shouldInject(field reflect.StructField) (injectTag, error) {
+	tagValue := field.Tag.Get("inject")
+	t := field.Type
 
-	switch injectTag {
-	case "":
-		inject = true
-	case "@none":
-		return false, nil
-	default:
-		errorMessage := fmt.Sprintf("Invalid `inject` tag value \"%s\" on member %s",
-			injectTag, t.String())
-		return false, errors.New(errorMessage)
+	tag := injectTag{inject: true}
+
+	if tagValue != "" {
+		for _, option := range strings.Split(tagValue, ",") {
+			switch {
+			case option == "@none":
+				return injectTag{}, nil
+			case option == "private":
+				tag.private = true
+			case strings.HasPrefix(option, "value=") && len(option) > len("value="):
+				tag.value = strings.TrimPrefix(option, "value=")
+			default:
+				errorMessage := fmt.Sprintf("Invalid `inject` tag value \"%s\" on member %s",
+					tagValue, t.String())
+				return injectTag{}, errors.New(errorMessage)
+			}
+		}
 	}
 
-	if !inject {
-		return false, nil
+	if tag.value != "" {
+		return tag, nil
 	}
 
 	canConstruct := isStructOrInterface(t)
 
 	if !canConstruct {
-		return false, nil
+		return injectTag{}, nil
 	}
 
-	return true, nil
+	return tag, nil
 }
 
 func (c *Container) Call(callback any) ([]reflect.Value, error) {
@@ -347,7 +1141,14 @@ func (c *Container) Call(callback any) ([]reflect.Value, error) {
 
 	var args []reflect.Value
 
-	for i := 0; i < funcType.NumIn(); i++ {
+	numIn := funcType.NumIn()
+
+	if funcType.IsVariadic() {
+		// variadic option parameters are left empty
+		numIn--
+	}
+
+	for i := 0; i < numIn; i++ {
 		argType := funcType.In(i)
 		arg, err := c.ResolveType(argType)
 
@@ -394,6 +1195,40 @@ func validateFactoryCallback(callback any) (reflect.Type, error) {
 	return reflect.TypeOf(nil), errors.New("callback must return an interface or a pointer to the constructed value")
 }
 
+func validateConstructor(callback any) (reflect.Type, bool, error) {
+	typeInfo := reflect.TypeOf(callback)
+
+	if typeInfo == nil || typeInfo.Kind() != reflect.Func {
+		return reflect.TypeOf(nil), false, errors.New("constructor must be a function")
+	}
+
+	numOut := typeInfo.NumOut()
+
+	if numOut < 1 || numOut > 3 {
+		return reflect.TypeOf(nil), false, errors.New("constructor must return T, (T, error) or (T, func(), error)")
+	}
+
+	if numOut > 1 && typeInfo.Out(numOut-1) != Type[error]() {
+		return reflect.TypeOf(nil), false, errors.New("the last return value of a constructor must be an error")
+	}
+
+	if numOut == 3 && typeInfo.Out(1) != Type[func()]() {
+		return reflect.TypeOf(nil), false, errors.New("the second return value of a constructor must be a cleanup func()")
+	}
+
+	returnType := typeInfo.Out(0)
+
+	switch returnType.Kind() {
+	case reflect.Pointer:
+		return returnType.Elem(), true, nil
+	case reflect.Interface:
+		return returnType, true, nil
+	}
+
+	// Values are copied on each resolution, unless a cleanup ties them to the container
+	return returnType, numOut == 3, nil
+}
+
 func hasLogLevel(value int, test int) bool {
 	return (value & test) != 0
 }
@@ -1,10 +1,15 @@
 package di
 
 import (
+	"errors"
 	"fmt"
+	"net/url"
 	"reflect"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestGetSetContainerFails(t *testing.T) {
@@ -141,7 +146,243 @@ func TestBuildTypeConcurrent(t *testing.T) {
 	wg.Wait()
 }
 
+type ConcurrentA struct{}
+
+type ConcurrentB struct{}
+
+type ConcurrentGate struct{}
+
+func TestResolveConcurrentCycle(t *testing.T) {
+	resetContainer()
+
+	var gate sync.WaitGroup
+	var calls atomic.Int32
+	gate.Add(2)
+
+	// both singletons are being built when they request each other
+	BindFactory(func() (*ConcurrentGate, error) {
+		if calls.Add(1) <= 2 {
+			gate.Done()
+			gate.Wait()
+		}
+
+		return &ConcurrentGate{}, nil
+	})
+	BindConstructor(func(_ *ConcurrentGate, _ *ConcurrentB) *ConcurrentA { return &ConcurrentA{} })
+	BindConstructor(func(_ *ConcurrentGate, _ *ConcurrentA) *ConcurrentB { return &ConcurrentB{} })
+
+	errs := make(chan error, 2)
+
+	go func() {
+		_, err := Resolve[ConcurrentA]()
+		errs <- err
+	}()
+
+	go func() {
+		_, err := Resolve[ConcurrentB]()
+		errs <- err
+	}()
+
+	for i := 0; i < 2; i++ {
+		select {
+		case err := <-errs:
+			if err == nil || !strings.Contains(err.Error(), "circular dependency") {
+				t.Error("Expected circular dependency error", err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("Concurrent circular dependencies deadlocked")
+		}
+	}
+}
+
 func TestIsNil(t *testing.T) {
 	var v reflect.Value
 	fmt.Println(v == reflect.Value{})
 }
+
+type InjectedStruct struct {
+	Thing1p    *Thing1
+	Thing1m    Thing1
+	NotSet     *Thing1 `inject:"@none"`
+	Name       string
+	initialize bool
+}
+
+func (i *InjectedStruct) Initialize() {
+	i.initialize = true
+}
+
+func TestInjectInto(t *testing.T) {
+	resetContainer()
+
+	thing1 := &Thing1{name: "THING1"}
+	BindInstance(thing1)
+
+	existing := &InjectedStruct{Name: "existing"}
+	err := GetContainer().InjectInto(existing)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if existing.Thing1p != thing1 || existing.Thing1m.name != "THING1" {
+		t.Error("Failed injecting into existing instance")
+	}
+
+	if existing.NotSet != nil || existing.Name != "existing" {
+		t.Error("Fields that are not injected should be left untouched")
+	}
+
+	if !existing.initialize {
+		t.Error("Initialize should have been called")
+	}
+
+	if GetContainer().HasRule(TypeId[InjectedStruct]()) {
+		t.Error("InjectInto should not register a rule")
+	}
+}
+
+func TestInjectIntoFails(t *testing.T) {
+	resetContainer()
+
+	if err := GetContainer().InjectInto(InjectedStruct{}); err == nil {
+		t.Error("InjectInto should fail on non-pointers")
+	}
+
+	var nilPtr *InjectedStruct
+
+	if err := GetContainer().InjectInto(nilPtr); err == nil {
+		t.Error("InjectInto should fail on nil pointers")
+	}
+}
+
+type MethodInjected struct {
+	thing1   *Thing1
+	alt      ITest
+	injected []string
+}
+
+func (m *MethodInjected) Inject(thing1 *Thing1) {
+	m.thing1 = thing1
+	m.injected = append(m.injected, "Inject")
+}
+
+func (m *MethodInjected) InjectAlt(alt ITest) error {
+	m.alt = alt
+	m.injected = append(m.injected, "InjectAlt")
+	return nil
+}
+
+func (m *MethodInjected) Injector() {
+	m.injected = append(m.injected, "Injector")
+}
+
+type MethodInjectedFails struct{}
+
+func (m *MethodInjectedFails) InjectThing(_ *Thing1) error {
+	return errors.New("inject failed")
+}
+
+func TestInjectMethods(t *testing.T) {
+	resetContainer()
+
+	thing1 := &Thing1{name: "THING1"}
+	BindInstance(thing1)
+	BindImpl[ITest](&Thing1Alt{subname: "alt"})
+	BindAuto[MethodInjected]()
+
+	m, err := Resolve[MethodInjected]()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if m.thing1 != thing1 || m.alt.test() != "alt" {
+		t.Error("Failed injecting through methods")
+	}
+
+	if len(m.injected) != 2 || m.injected[0] != "Inject" || m.injected[1] != "InjectAlt" {
+		t.Error("Only Inject and InjectXxx methods should be called", m.injected)
+	}
+}
+
+func TestInjectMethodsFail(t *testing.T) {
+	resetContainer()
+
+	BindAuto[MethodInjectedFails]()
+
+	if _, err := Resolve[MethodInjectedFails](); err == nil {
+		t.Error("Unresolvable Inject parameters should fail")
+	}
+
+	BindInstance(&Thing1{})
+
+	if _, err := Resolve[MethodInjectedFails](); err == nil || !strings.Contains(err.Error(), "inject failed") {
+		t.Error("Errors returned by Inject methods should be propagated", err)
+	}
+}
+
+type PrivateInjected struct {
+	thing1   *Thing1 `inject:"private"`
+	untagged *Thing1
+}
+
+func TestPrivateInjection(t *testing.T) {
+	resetContainer()
+
+	thing1 := &Thing1{name: "THING1"}
+	BindInstance(thing1)
+	BindAuto[PrivateInjected]()
+
+	if Instance[PrivateInjected]().thing1 != nil {
+		t.Error("Private members should not be set unless the package opts in")
+	}
+
+	other := NewContainer()
+	AllowPrivateInjection[PrivateInjected](other)
+
+	BindAuto[PrivateInjected]()
+
+	if Instance[PrivateInjected]().thing1 != nil {
+		t.Error("Private injection should only be allowed in the given container")
+	}
+
+	AllowPrivateInjection[PrivateInjected]()
+
+	BindAuto[PrivateInjected]()
+	object1 := Instance[PrivateInjected]()
+
+	if object1.thing1 != thing1 {
+		t.Error("Failed injecting tagged private member")
+	}
+
+	if object1.untagged != nil {
+		t.Error("Untagged private members should not be set")
+	}
+}
+
+func TestPrivateInjectionOtherPackage(t *testing.T) {
+	assertPanics(t, "private injection of url.URL can only be allowed by its package net/url, not by github.com/quasi-go/di", func() {
+		AllowPrivateInjection[url.URL]()
+	})
+
+	if GetContainer().isPrivateInjectionAllowed(Type[url.URL]()) {
+		t.Error("Other packages should not opt in")
+	}
+}
+
+func TestFuncPackage(t *testing.T) {
+	names := map[string]string{
+		"github.com/org/pkg.(*T).Method":     "github.com/org/pkg",
+		"github.com/org/pkg.init.0":          "github.com/org/pkg",
+		"gopkg.in/yaml%2ev3.Unmarshal":       "gopkg.in/yaml.v3",
+		"example.com/dotted%2ev2.init.func1": "example.com/dotted.v2",
+		"main.main":                          "main",
+	}
+
+	for name, expected := range names {
+		if pkg := funcPackage(name); pkg != expected {
+			t.Error("Failed asserting package of", name, pkg)
+		}
+	}
+}
@@ -1,9 +1,11 @@
 package di
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
+	"log/slog"
 	"reflect"
 )
 
@@ -76,71 +78,185 @@ func ResolveImpl[T any]() (T, error) {
 	return ConvertImpl[T](built)
 }
 
-func BindInstance[T any](instance *T) {
-	GetContainer().SetRule(
+func BindInstance[T any](instance *T, options ...BindOption) {
+	binderOf(options).Bind(
 		TypeId[T](),
 		&instanceRule{reflect.ValueOf(instance)},
+		options...,
 	)
 }
 
-func BindImpl[T any, U any](impl *U) {
+func BindImpl[T any, U any](impl *U, options ...BindOption) {
 	validateImpl[T, U]()
 
-	GetContainer().SetRule(
+	binderOf(options).Bind(
 		TypeId[T](),
 		&instanceRule{reflect.ValueOf(impl)},
+		options...,
 	)
 }
 
-func BindType[T any, U any]() {
+// BindImplValue binds T to impl, a value implementing T such as a function type with
+// methods. When only *U implements T, a copy of impl is bound.
+func BindImplValue[T any, U any](impl U, options ...BindOption) {
 	validateImpl[T, U]()
 
-	if !GetContainer().HasRule(TypeId[U]()) {
-		GetContainer().SetRule(
+	instance := reflect.ValueOf(impl)
+
+	if !Type[U]().Implements(Type[T]()) {
+		instance = reflect.New(Type[U]())
+		instance.Elem().Set(reflect.ValueOf(impl))
+	}
+
+	binderOf(options).Bind(
+		TypeId[T](),
+		&instanceRule{instance},
+		options...,
+	)
+}
+
+func BindType[T any, U any](options ...BindOption) {
+	validateImpl[T, U]()
+
+	// the zero value of these kinds is nil, so they have to be bound as values
+	switch Type[U]().Kind() {
+	case reflect.Func, reflect.Chan, reflect.Map, reflect.Pointer:
+		panic(fmt.Sprintf("%s can't be built as it is a %s, use BindImplValue", Type[U](), Type[U]().Kind()))
+	}
+
+	binder := binderOf(options)
+
+	if !binder.HasRule(TypeId[U]()) {
+		binder.Bind(
 			TypeId[U](),
 			&autoRule{typeTo: Type[U]()},
+			options...,
 		)
 	}
 
-	GetContainer().SetRule(
+	binder.Bind(
 		TypeId[T](),
-		&typeRule{Type[U]()},
+		&typeRule{typeTo: Type[U](), value: isValueImpl(Type[T](), Type[U]())},
+		options...,
 	)
 }
 
-func BindAuto[T any]() {
-	GetContainer().SetRule(
+// BindDefault binds U as the implementation of T, unless T has a regular binding.
+func BindDefault[T any, U any](options ...BindOption) {
+	BindType[T, U](append(options[:len(options):len(options)], Default())...)
+}
+
+func BindAuto[T any](options ...BindOption) {
+	binderOf(options).Bind(
 		TypeId[T](),
 		&autoRule{typeTo: Type[T]()},
+		options...,
 	)
 }
 
-func BindFactory(callback any) {
+func BindFactory(callback any, options ...BindOption) {
 	returnType, err := validateFactoryCallback(callback)
 
 	if err != nil {
 		panic(err.Error())
 	}
 
-	GetContainer().SetRule(
-		Id(returnType.String()),
+	binderOf(options).Bind(
+		typeKey(returnType),
 		&factoryRule{callback},
+		options...,
 	)
 }
 
-func BindProvider(callback any) {
+func BindProvider(callback any, options ...BindOption) {
 	returnType, err := validateFactoryCallback(callback)
 
 	if err != nil {
 		panic(err.Error())
 	}
 
-	GetContainer().SetRule(
-		Id(returnType.String()),
+	binderOf(options).Bind(
+		typeKey(returnType),
 		&providerRule{factoryRule: factoryRule{callback}},
+		options...,
+	)
+}
+
+func BindConstructor(constructor any, options ...BindOption) {
+	returnType, singleton, err := validateConstructor(constructor)
+
+	if err != nil {
+		panic(err.Error())
+	}
+
+	binderOf(options).Bind(
+		typeKey(returnType),
+		&constructorRule{callback: constructor, singleton: singleton},
+		options...,
+	)
+}
+
+func BindValue[T any](name string, value T, options ...BindOption) {
+	binderOf(options).Bind(
+		ValueId(name),
+		// typed nils such as a nil error keep their type
+		&valueRule{reflect.ValueOf(&value).Elem()},
+		options...,
 	)
 }
 
+func ResolveValue[T any](name string) (T, error) {
+	c := GetContainer()
+	key := ValueId(name)
+
+	if !c.HasRule(key) {
+		return *new(T), fmt.Errorf("value %s not found", name)
+	}
+
+	value, err := c.GetRule(key).Resolve(c)
+
+	if err != nil {
+		return *new(T), err
+	}
+
+	converted, err := convertValue(value, Type[T]())
+
+	if err != nil {
+		return *new(T), fmt.Errorf("could not resolve value %s: %w", name, err)
+	}
+
+	// a nil interface is returned as the zero T
+	result, _ := converted.Interface().(T)
+
+	return result, nil
+}
+
+func Value[T any](name string) T {
+	value, err := ResolveValue[T](name)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return value
+}
+
+func Unbind[T any](options ...UnbindOption) ([]Id, error) {
+	return GetContainer().Unbind(TypeId[T](), options...)
+}
+
+func Validate() (*ValidationReport, error) {
+	return GetContainer().Validate()
+}
+
+func Bindings() []BindingInfo {
+	return GetContainer().Bindings()
+}
+
+func Install(modules ...Module) error {
+	return GetContainer().Install(modules...)
+}
+
 func Instance[T any]() *T {
 	inst, err := Resolve[T]()
 
@@ -162,9 +278,17 @@ func Impl[T any]() T {
 }
 
 func Reset() {
+	if GetContainer().IsFrozen() {
+		panic("can't reset, the container is frozen")
+	}
+
 	resetContainer()
 }
 
+func Freeze() {
+	GetContainer().Freeze()
+}
+
 func Invoke(callback any) {
 	_, err := GetContainer().Call(callback)
 
@@ -173,10 +297,71 @@ func Invoke(callback any) {
 	}
 }
 
+func InjectInto(ptr any) error {
+	return GetContainer().InjectInto(ptr)
+}
+
+// AllowPrivateInjection lets the container of the options, the current one by default, set
+// the members tagged with `inject:"private"` of every type in the package of T.
+func AllowPrivateInjection[T any](options ...BindOption) {
+	typeInfo := Type[T]()
+
+	if typeInfo.Kind() == reflect.Pointer {
+		typeInfo = typeInfo.Elem()
+	}
+
+	if typeInfo.PkgPath() == "" {
+		panic(fmt.Sprintf("%s is not a named type of a package", typeInfo))
+	}
+
+	if caller := callerPackage(); caller != typeInfo.PkgPath() {
+		panic(fmt.Sprintf("private injection of %s can only be allowed by its package %s, not by %s",
+			typeInfo, typeInfo.PkgPath(), caller))
+	}
+
+	binderOf(options).Container().allowPrivateInjection(typeInfo.PkgPath())
+}
+
+func Start(ctx context.Context) error {
+	return GetContainer().Start(ctx)
+}
+
+func Run(ctx context.Context) error {
+	return GetContainer().Run(ctx)
+}
+
+func Health(ctx context.Context) HealthReport {
+	return GetContainer().Health(ctx)
+}
+
+func Close() {
+	GetContainer().Close()
+}
+
+func AddHooks(hooks Hooks) {
+	GetContainer().AddHooks(hooks)
+}
+
+func EnableStartupReport() {
+	GetContainer().EnableStartupReport()
+}
+
+func GetStartupReport() *StartupReport {
+	return GetContainer().StartupReport()
+}
+
 func SetLogger(logger *log.Logger) {
 	GetContainer().SetLogger(logger)
 }
 
+func SetStructuredLogger(logger Logger) {
+	GetContainer().SetStructuredLogger(logger)
+}
+
+func SetLogHandler(handler slog.Handler) {
+	GetContainer().SetLogHandler(handler)
+}
+
 func SetLogLevel(logLevel int) {
 	GetContainer().SetLogLevel(logLevel)
 }
@@ -187,8 +372,14 @@ func validateImpl[T any, U any]() {
 		panic(message)
 	}
 
-	if !Type[*U]().Implements(Type[T]()) {
-		message := fmt.Sprintf("*%s does not implement %s", Type[U](), Type[T]())
+	if !Type[U]().Implements(Type[T]()) && !Type[*U]().Implements(Type[T]()) {
+		message := fmt.Sprintf("neither %s nor *%s implement %s", Type[U](), Type[U](), Type[T]())
 		panic(message)
 	}
 }
+
+// isValueImpl reports whether implementations of iface by impl are values rather than
+// pointers, which is the case for named types that are not structs with value receivers.
+func isValueImpl(iface reflect.Type, impl reflect.Type) bool {
+	return impl.Kind() != reflect.Struct && impl.Kind() != reflect.Pointer && impl.Implements(iface)
+}
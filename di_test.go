@@ -1,8 +1,12 @@
 package di
 
 import (
+	"errors"
+	"fmt"
 	"reflect"
+	"regexp"
 	"testing"
+	"time"
 )
 
 type Thing1 struct {
@@ -318,3 +322,284 @@ func TestInvokeModifyScopeVariable(t *testing.T) {
 		t.Error("name should have been set by Invoke")
 	}
 }
+
+type ConstructorOption func(*Thing1Alt)
+
+func NewThing1Alt(thing1 *Thing1, options ...ConstructorOption) *Thing1Alt {
+	alt := &Thing1Alt{subname: thing1.name}
+
+	for _, option := range options {
+		option(alt)
+	}
+
+	return alt
+}
+
+func TestBindConstructor(t *testing.T) {
+	Reset()
+
+	BindInstance(&Thing1{name: "constructed"})
+	BindConstructor(NewThing1Alt)
+
+	alt, err := Resolve[Thing1Alt]()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if alt.subname != "constructed" {
+		t.Error("Failed asserting constructed object")
+	}
+
+	if alt != Instance[Thing1Alt]() {
+		t.Error("Constructors returning pointers should be singletons")
+	}
+}
+
+func TestBindConstructorValue(t *testing.T) {
+	Reset()
+
+	count := 0
+
+	BindConstructor(func() (Thing1, error) {
+		count++
+		return Thing1{name: fmt.Sprintf("%d times", count)}, nil
+	})
+
+	first := Instance[Thing1]()
+	second := Instance[Thing1]()
+
+	if first == second || first.name != "1 times" || second.name != "2 times" {
+		t.Error("Constructors returning values should build a new value each time")
+	}
+}
+
+func TestBindConstructorError(t *testing.T) {
+	Reset()
+
+	BindConstructor(func() (*Thing1, error) {
+		return nil, errors.New("failed")
+	})
+
+	if _, err := Resolve[Thing1](); err == nil || !regexp.MustCompile(`^di.Thing1 \(bound at .*di_test.go:\d+\): failed$`).MatchString(err.Error()) {
+		t.Error("Constructor errors should be returned with the binding site", err)
+	}
+}
+
+func TestBindFactoryError(t *testing.T) {
+	Reset()
+
+	failed := errors.New("failed")
+	calls := 0
+
+	BindFactory(func() (*Thing1, error) {
+		calls++
+		return nil, failed
+	})
+
+	if _, err := Resolve[Thing1](); !errors.Is(err, failed) {
+		t.Error("Factory errors should be returned", err)
+	}
+
+	BindProvider(func() (*Thing1, error) {
+		calls++
+
+		if calls == 2 {
+			return nil, failed
+		}
+
+		return &Thing1{name: "provided"}, nil
+	})
+
+	if _, err := Resolve[Thing1](); !errors.Is(err, failed) {
+		t.Error("Provider errors should be returned", err)
+	}
+
+	if thing, err := Resolve[Thing1](); err != nil || thing.name != "provided" {
+		t.Error("Failed providers should be called again", thing, err)
+	}
+}
+
+func TestBindConstructorCleanup(t *testing.T) {
+	Reset()
+
+	var closed []string
+
+	BindConstructor(func() (*Thing1, func(), error) {
+		return &Thing1{name: "first"}, func() { closed = append(closed, "first") }, nil
+	})
+
+	BindConstructor(func(thing1 *Thing1) (*Thing1Alt, func(), error) {
+		return &Thing1Alt{subname: thing1.name}, func() { closed = append(closed, "second") }, nil
+	})
+
+	Instance[Thing1Alt]()
+	Instance[Thing1Alt]()
+	Close()
+
+	if len(closed) != 2 || closed[0] != "second" || closed[1] != "first" {
+		t.Error("Cleanups should run once in reverse order", closed)
+	}
+}
+
+func TestBindConstructorFails(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic")
+		}
+	}()
+
+	BindConstructor(func() (*Thing1, string) {
+		return nil, ""
+	})
+}
+
+func TestBindDefault(t *testing.T) {
+	resetContainer()
+	GetContainer().SetStrictBinding(true)
+
+	BindDefault[ConditionalStore, MemoryStore]()
+
+	if store := Impl[ConditionalStore](); store.Name() != "memory" {
+		t.Error("Failed asserting default binding", store.Name())
+	}
+
+	BindType[ConditionalStore, PostgresStore]()
+
+	if store := Impl[ConditionalStore](); store.Name() != "postgres" {
+		t.Error("Regular bindings should be preferred to defaults", store.Name())
+	}
+
+	BindDefault[ConditionalStore, MemoryStore](Priority(10))
+
+	if store := Impl[ConditionalStore](); store.Name() != "postgres" {
+		t.Error("Defaults should not win by priority", store.Name())
+	}
+}
+
+func TestBindPriority(t *testing.T) {
+	resetContainer()
+	GetContainer().SetStrictBinding(true)
+
+	err := Install(
+		NewModule("postgres", func(b Binder) {
+			BindType[ConditionalStore, PostgresStore](b, Priority(10))
+		}),
+		NewModule("memory", func(b Binder) {
+			BindType[ConditionalStore, MemoryStore](b, When(Profile("dev")))
+		}),
+	)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	GetContainer().SetProfiles("dev")
+
+	if store := Impl[ConditionalStore](); store.Name() != "postgres" {
+		t.Error("Failed asserting highest priority", store.Name())
+	}
+
+	BindType[ConditionalStore, MemoryStore](Priority(10), When(Profile("dev")))
+
+	if store := Impl[ConditionalStore](); store.Name() != "memory" {
+		t.Error("Conditions should break priority ties", store.Name())
+	}
+}
+
+type Nower interface {
+	Now() time.Time
+}
+
+type Clock func() time.Time
+
+func (c Clock) Now() time.Time {
+	return c()
+}
+
+type Greeter interface {
+	Greet() string
+}
+
+type ValueGreeter struct {
+	Name string
+}
+
+func (g ValueGreeter) Greet() string {
+	return "hello " + g.Name
+}
+
+type Level int
+
+func (l Level) Greet() string {
+	return fmt.Sprintf("level %d", l)
+}
+
+type PointerLevel int
+
+func (l *PointerLevel) Greet() string {
+	return fmt.Sprintf("pointer level %d", *l)
+}
+
+func TestBindImplValue(t *testing.T) {
+	resetContainer()
+
+	moment := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	BindImplValue[Nower](Clock(func() time.Time { return moment }))
+
+	nower := Impl[Nower]()
+
+	if _, ok := nower.(Clock); !ok || nower.Now() != moment {
+		t.Error("Failed asserting function type implementation", nower)
+	}
+
+	BindImplValue[Greeter](ValueGreeter{Name: "value"})
+
+	if greeter, ok := Impl[Greeter]().(ValueGreeter); !ok || greeter.Greet() != "hello value" {
+		t.Error("Failed asserting value implementation", greeter)
+	}
+
+	BindImplValue[Greeter](PointerLevel(3))
+
+	if greeter, ok := Impl[Greeter]().(*PointerLevel); !ok || greeter.Greet() != "pointer level 3" {
+		t.Error("Failed asserting pointer receiver implementation", greeter)
+	}
+
+	level := PointerLevel(4)
+	BindImpl[Greeter, PointerLevel](&level)
+
+	if greeter := Impl[Greeter](); greeter != &level {
+		t.Error("BindImpl should bind the given pointer", greeter)
+	}
+}
+
+func TestBindTypeValue(t *testing.T) {
+	resetContainer()
+
+	BindType[Greeter, Level]()
+
+	if greeter, ok := Impl[Greeter]().(Level); !ok || greeter.Greet() != "level 0" {
+		t.Error("Failed asserting named type implementation", greeter)
+	}
+
+	level := Level(2)
+	BindInstance(&level)
+
+	if greeter := Impl[Greeter](); greeter.Greet() != "level 2" {
+		t.Error("Failed asserting bound named type", greeter)
+	}
+
+	BindType[Greeter, ValueGreeter]()
+
+	if greeter, ok := Impl[Greeter]().(*ValueGreeter); !ok || greeter.Greet() != "hello " {
+		t.Error("Structs should be resolved as pointers", greeter)
+	}
+
+	assertPanics(t, "neither di.Thing1 nor *di.Thing1 implement di.Greeter", func() {
+		BindType[Greeter, Thing1]()
+	})
+
+	assertPanics(t, "di.Clock can't be built as it is a func, use BindImplValue", func() {
+		BindType[Nower, Clock]()
+	})
+}
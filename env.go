@@ -0,0 +1,176 @@
+package di
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+)
+
+type EnvError struct {
+	Errors []error
+}
+
+func (e *EnvError) Error() string {
+	messages := make([]string, len(e.Errors))
+
+	for i, err := range e.Errors {
+		messages[i] = err.Error()
+	}
+
+	return "invalid environment: " + strings.Join(messages, "; ")
+}
+
+type envTag struct {
+	name         string
+	defaultValue string
+	hasDefault   bool
+	required     bool
+}
+
+func parseEnvTag(tagValue string) (envTag, error) {
+	parts := strings.Split(tagValue, ",")
+	tag := envTag{name: strings.TrimSpace(parts[0])}
+
+	for _, part := range parts[1:] {
+		switch {
+		case strings.HasPrefix(part, "default="):
+			tag.defaultValue = strings.TrimPrefix(part, "default=")
+			tag.hasDefault = true
+		case part == "required":
+			tag.required = true
+		case tag.hasDefault:
+			// defaults may contain commas
+			tag.defaultValue += "," + part
+		default:
+			return envTag{}, fmt.Errorf("invalid `env` tag option \"%s\"", part)
+		}
+	}
+
+	return tag, nil
+}
+
+func walkEnv(target reflect.Value, prefix string, visit func(name string, tag envTag, field reflect.Value) error) []error {
+	var errs []error
+
+	typeInfo := target.Type()
+
+	for i := 0; i < typeInfo.NumField(); i++ {
+		typeField := typeInfo.Field(i)
+		field := target.Field(i)
+		tagValue, hasTag := typeField.Tag.Lookup("env")
+
+		if !typeField.IsExported() || tagValue == "-" {
+			continue
+		}
+
+		tag, err := parseEnvTag(tagValue)
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s.%s: %w", typeInfo, typeField.Name, err))
+			continue
+		}
+
+		if isEnvStruct(typeField.Type) {
+			nestedPrefix := prefix
+
+			if tag.name != "" {
+				nestedPrefix += tag.name + "_"
+			}
+
+			if field.Kind() == reflect.Pointer {
+				if field.IsNil() {
+					field.Set(reflect.New(field.Type().Elem()))
+				}
+
+				field = field.Elem()
+			}
+
+			errs = append(errs, walkEnv(field, nestedPrefix, visit)...)
+			continue
+		}
+
+		if !hasTag || tag.name == "" {
+			continue
+		}
+
+		if err := visit(prefix+tag.name, tag, field); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+func loadEnv(target reflect.Value, prefix string, lookup func(string) (string, bool)) []error {
+	return walkEnv(target, prefix, func(name string, tag envTag, field reflect.Value) error {
+		value, found := lookup(name)
+
+		if !found {
+			// a default satisfies required, as with LoadConfig
+			if !tag.hasDefault {
+				if tag.required {
+					return fmt.Errorf("%s is required", name)
+				}
+
+				return nil
+			}
+
+			value = tag.defaultValue
+		}
+
+		return setEnvValue(name, value, field)
+	})
+}
+
+func setEnvValue(name string, value string, field reflect.Value) error {
+	parsed, err := parseValue(value, field.Type())
+
+	if err != nil {
+		return fmt.Errorf("%s has an invalid value \"%s\": %w", name, value, err)
+	}
+
+	field.Set(parsed)
+
+	return nil
+}
+
+func isEnvStruct(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	// structs such as time.Time are parsed as values, not walked
+	return t.Kind() == reflect.Struct && t.PkgPath() != "time"
+}
+
+func LoadEnv[T any](prefix string) (*T, error) {
+	typeInfo := Type[T]()
+
+	if typeInfo.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%s must be a struct", typeInfo)
+	}
+
+	config := new(T)
+	errs := loadEnv(reflect.ValueOf(config).Elem(), prefix, os.LookupEnv)
+
+	if len(errs) > 0 {
+		return nil, &EnvError{errs}
+	}
+
+	return config, nil
+}
+
+func BindEnv[T any](prefix string, options ...BindOption) {
+	if Type[T]().Kind() != reflect.Struct {
+		panic(fmt.Sprintf("%s must be a struct", Type[T]()))
+	}
+
+	binderOf(options).Bind(
+		TypeId[T](),
+		&providerRule{factoryRule: factoryRule{func() (*T, error) {
+			return LoadEnv[T](prefix)
+		}}},
+		options...,
+	)
+}
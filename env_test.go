@@ -0,0 +1,117 @@
+package di
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type EnvDB struct {
+	Host     string `env:"HOST,default=localhost"`
+	Port     int    `env:"PORT,default=5432"`
+	Password string `env:"PASSWORD,required"`
+}
+
+type EnvConfig struct {
+	Name    string        `env:"NAME"`
+	Timeout time.Duration `env:"TIMEOUT,default=5s"`
+	Tags    []string      `env:"TAGS,default=a,b"`
+	DB      EnvDB         `env:"DB"`
+	Cache   *EnvDB        `env:"CACHE"`
+	Ignored string
+}
+
+func TestLoadEnv(t *testing.T) {
+	t.Setenv("TEST_NAME", "app")
+	t.Setenv("TEST_DB_PORT", "6543")
+	t.Setenv("TEST_DB_PASSWORD", "secret")
+	t.Setenv("TEST_CACHE_PASSWORD", "cache")
+
+	config, err := LoadEnv[EnvConfig]("TEST_")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if config.Name != "app" || config.Timeout != 5*time.Second || !reflect.DeepEqual(config.Tags, []string{"a", "b"}) {
+		t.Error("Failed loading environment", config)
+	}
+
+	if config.DB.Host != "localhost" || config.DB.Port != 6543 || config.DB.Password != "secret" {
+		t.Error("Failed loading nested environment", config.DB)
+	}
+
+	if config.Cache == nil || config.Cache.Password != "cache" {
+		t.Error("Failed loading nested pointer environment", config.Cache)
+	}
+}
+
+type EnvRequiredDefault struct {
+	Host string `env:"DB_HOST,default=localhost,required"`
+}
+
+func TestLoadEnvRequiredDefault(t *testing.T) {
+	config, err := LoadEnv[EnvRequiredDefault]("TEST_")
+
+	if err != nil || config.Host != "localhost" {
+		t.Error("Defaults should satisfy required variables", config, err)
+	}
+
+	loaded, err := LoadConfig[EnvRequiredDefault](EnvVars("TEST_"))
+
+	if err != nil || loaded.Host != "localhost" {
+		t.Error("LoadConfig should treat required defaults like LoadEnv", loaded, err)
+	}
+}
+
+func TestLoadEnvFails(t *testing.T) {
+	t.Setenv("TEST_TIMEOUT", "soon")
+	t.Setenv("TEST_DB_PORT", "port")
+
+	_, err := LoadEnv[EnvConfig]("TEST_")
+
+	var envErr *EnvError
+
+	if !errors.As(err, &envErr) {
+		t.Fatal("Expected an EnvError", err)
+	}
+
+	if len(envErr.Errors) != 4 {
+		t.Error("Every invalid or missing variable should be reported", err)
+	}
+
+	for _, name := range []string{"TEST_TIMEOUT", "TEST_DB_PORT", "TEST_DB_PASSWORD", "TEST_CACHE_PASSWORD"} {
+		if !strings.Contains(err.Error(), name) {
+			t.Error("Missing error for", name)
+		}
+	}
+}
+
+func TestBindEnv(t *testing.T) {
+	Reset()
+
+	t.Setenv("TEST_DB_PASSWORD", "secret")
+	BindEnv[EnvDB]("TEST_DB_")
+
+	config, err := Resolve[EnvDB]()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if config.Password != "secret" || config != Instance[EnvDB]() {
+		t.Error("Failed resolving bound environment")
+	}
+}
+
+func TestBindEnvFails(t *testing.T) {
+	Reset()
+
+	BindEnv[EnvDB]("TEST_DB_")
+
+	if _, err := Resolve[EnvDB](); err == nil {
+		t.Error("Missing required variables should fail resolution")
+	}
+}
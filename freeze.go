@@ -0,0 +1,15 @@
+package di
+
+// Freeze seals the bindings of the container, typically once bootstrapping is done.
+// Binding afterwards fails, and rules are then read without locking.
+func (c *Container) Freeze() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.frozen.Store(true)
+	c.log(LogLevelTrace, "container frozen")
+}
+
+func (c *Container) IsFrozen() bool {
+	return c.frozen.Load()
+}
@@ -0,0 +1,69 @@
+package di
+
+import (
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestFreeze(t *testing.T) {
+	resetContainer()
+
+	BindAuto[ModuleStore]()
+	BindType[ModuleRepository, ModuleRepositoryImpl]()
+	Freeze()
+
+	if !GetContainer().IsFrozen() {
+		t.Error("Failed asserting frozen container")
+	}
+
+	if err := GetContainer().SetRule(TypeId[ModuleStore](), &autoRule{typeTo: Type[ModuleStore]()}); err == nil || !strings.Contains(err.Error(), "the container is frozen") {
+		t.Error("Failed asserting SetRule error", err)
+	}
+
+	if err := Install(newStorageModule()); err == nil {
+		t.Error("Failed asserting Install error")
+	}
+
+	assertPanics(t, "can't bind di.ModuleHandler, the container is frozen", func() {
+		BindAuto[ModuleHandler]()
+	})
+
+	assertPanics(t, "can't bind di.FileConfig, the container is frozen", func() {
+		BindConfig[FileConfig](nil)
+	})
+
+	assertPanics(t, "the container is frozen", func() {
+		BindWatched[FileConfig](nil)
+	})
+
+	assertPanics(t, "can't reset, the container is frozen", Reset)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if repository := Impl[ModuleRepository](); repository.Store() == nil {
+				t.Error("Failed resolving frozen container")
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+func assertPanics(t *testing.T, message string, callback func()) {
+	t.Helper()
+
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(r.(string), message) {
+			t.Error("Failed asserting panic", message, r)
+		}
+	}()
+
+	callback()
+}
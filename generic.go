@@ -0,0 +1,95 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// genericRule is the template of the instantiations of a generic struct
+type genericRule struct {
+	template reflect.Type
+}
+
+func (r *genericRule) Resolve(_ *Container) (reflect.Value, error) {
+	return reflect.Value{}, fmt.Errorf("%s is a generic template, only its instantiations can be resolved", r.template)
+}
+
+type genericInstance struct {
+	template *binding
+	binding  *binding
+}
+
+// BindGeneric binds every instantiation of a generic struct, given by any of them like
+// `Repository[any]`. They are built automatically on first use, as with BindAuto.
+func BindGeneric[T any](options ...BindOption) {
+	if Type[T]().Kind() != reflect.Struct {
+		panic(fmt.Sprintf("%s must be a struct", Type[T]()))
+	}
+
+	base, isGeneric := genericBase(TypeId[T]())
+
+	if !isGeneric {
+		panic(fmt.Sprintf("%s is not a generic type", Type[T]()))
+	}
+
+	binderOf(options).Bind(
+		GenericId(base),
+		&genericRule{Type[T]()},
+		options...,
+	)
+}
+
+// genericTemplate returns the active template of the generic type of id
+func (c *Container) genericTemplate(id Id) *binding {
+	base, isGeneric := genericBase(id)
+
+	if !isGeneric {
+		return nil
+	}
+
+	return c.selectBinding(c.alternatives(GenericId(base)))
+}
+
+// instantiated returns the binding of a generic instantiation that was already resolved
+func (c *Container) instantiated(id Id) *binding {
+	template := c.genericTemplate(id)
+
+	if template == nil {
+		return nil
+	}
+
+	if instance, ok := c.generics.Load(id); ok && instance.(*genericInstance).template == template {
+		return instance.(*genericInstance).binding
+	}
+
+	return nil
+}
+
+// instantiate binds an instantiation of a template on demand. They are kept apart from
+// the rules, which can't change once the container is frozen.
+func (c *Container) instantiate(id Id, typeInfo reflect.Type) *binding {
+	if b := c.instantiated(id); b != nil {
+		return b
+	}
+
+	template := c.genericTemplate(id)
+
+	if template == nil || typeInfo == nil || typeInfo.Kind() != reflect.Struct ||
+		typeInfo.PkgPath() != template.rule.(*genericRule).template.PkgPath() {
+		return nil
+	}
+
+	instance := &genericInstance{template, &binding{
+		rule:     &autoRule{typeTo: typeInfo},
+		module:   template.module,
+		exported: template.exported,
+		source:   template.source,
+	}}
+
+	// a concurrent resolution may have instantiated it first
+	if current, loaded := c.generics.LoadOrStore(id, instance); loaded && current.(*genericInstance).template != template {
+		c.generics.CompareAndSwap(id, current, instance)
+	}
+
+	return c.instantiated(id)
+}
@@ -0,0 +1,99 @@
+package di
+
+import (
+	"strings"
+	"testing"
+)
+
+type GenericUser struct{}
+
+type GenericOrder struct{}
+
+type GenericRepository[T any] struct {
+	Store *ModuleStore
+	items []T
+}
+
+type GenericService struct {
+	Users  *GenericRepository[GenericUser]
+	Orders GenericRepository[GenericOrder]
+}
+
+func TestBindAutoGeneric(t *testing.T) {
+	resetContainer()
+
+	BindAuto[ModuleStore]()
+	BindAuto[GenericRepository[GenericUser]]()
+
+	if repository := Instance[GenericRepository[GenericUser]](); repository.Store == nil {
+		t.Error("Failed asserting generic auto binding")
+	}
+
+	if _, err := Resolve[GenericRepository[GenericOrder]](); err == nil {
+		t.Error("Other instantiations should not be bound")
+	}
+}
+
+func TestBindGeneric(t *testing.T) {
+	resetContainer()
+
+	BindAuto[ModuleStore]()
+	BindGeneric[GenericRepository[any]]()
+	BindAuto[GenericService]()
+
+	service := Instance[GenericService]()
+
+	if service.Users == nil || service.Users.Store == nil || service.Orders.Store == nil {
+		t.Fatal("Failed asserting generic instantiations", service)
+	}
+
+	if Instance[GenericRepository[GenericUser]]() != service.Users {
+		t.Error("Instantiations should be singletons")
+	}
+
+	var generic, instantiation bool
+
+	for _, b := range Bindings() {
+		if b.Id == GenericId("di.GenericRepository") && b.Kind == "generic" {
+			generic = true
+		}
+
+		if b.Id == TypeId[GenericRepository[GenericUser]]() && b.Kind == "auto" && b.Built {
+			instantiation = true
+		}
+	}
+
+	if !generic || !instantiation {
+		t.Error("Failed asserting generic bindings", Bindings())
+	}
+
+	if _, err := GetContainer().ResolveType(Type[GenericRepository[any]]()); err != nil {
+		t.Error("Failed asserting template instantiation", err)
+	}
+}
+
+func TestBindGenericFrozen(t *testing.T) {
+	resetContainer()
+
+	BindAuto[ModuleStore]()
+	BindGeneric[GenericRepository[any]]()
+	Freeze()
+
+	if repository := Instance[GenericRepository[GenericOrder]](); repository.Store == nil {
+		t.Error("Failed asserting instantiation of frozen container")
+	}
+}
+
+func TestBindGenericFails(t *testing.T) {
+	resetContainer()
+
+	assertPanics(t, "di.ModuleStore is not a generic type", func() {
+		BindGeneric[ModuleStore]()
+	})
+
+	GetContainer().SetRule(GenericId("di.GenericRepository"), &genericRule{Type[GenericRepository[any]]()})
+
+	if _, err := GetContainer().resolve(GenericId("di.GenericRepository"), nil); err == nil || !strings.Contains(err.Error(), "is a generic template") {
+		t.Error("Failed asserting template resolution error", err)
+	}
+}
@@ -0,0 +1,132 @@
+package di
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sync"
+	"time"
+)
+
+const (
+	HealthStatusUp   = "up"
+	HealthStatusDown = "down"
+)
+
+const DefaultHealthTimeout = 5 * time.Second
+
+type HealthChecker interface {
+	Health(ctx context.Context) error
+}
+
+type ComponentHealth struct {
+	Name     string        `json:"name"`
+	Status   string        `json:"status"`
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
+type HealthReport struct {
+	Status     string            `json:"status"`
+	Components []ComponentHealth `json:"components"`
+}
+
+func (c *Container) SetHealthTimeout(timeout time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.healthTimeout = timeout
+}
+
+// Health checks every built instance implementing HealthChecker concurrently. The
+// report is down if any check fails or does not return within the health timeout.
+func (c *Container) Health(ctx context.Context) HealthReport {
+	c.mutex.Lock()
+	timeout := c.healthTimeout
+	c.mutex.Unlock()
+
+	if timeout <= 0 {
+		timeout = DefaultHealthTimeout
+	}
+
+	var checkers []reflect.Value
+
+	for _, instance := range c.builtInstances() {
+		if _, ok := instance.Interface().(HealthChecker); ok {
+			checkers = append(checkers, instance)
+		}
+	}
+
+	report := HealthReport{
+		Status:     HealthStatusUp,
+		Components: make([]ComponentHealth, len(checkers)),
+	}
+
+	var wg sync.WaitGroup
+
+	for i, instance := range checkers {
+		wg.Add(1)
+
+		go func(i int, instance reflect.Value) {
+			defer wg.Done()
+
+			report.Components[i] = checkHealth(ctx, instance, timeout)
+		}(i, instance)
+	}
+
+	wg.Wait()
+
+	for _, component := range report.Components {
+		if component.Status != HealthStatusUp {
+			report.Status = HealthStatusDown
+		}
+	}
+
+	return report
+}
+
+func checkHealth(ctx context.Context, instance reflect.Value, timeout time.Duration) ComponentHealth {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	component := ComponentHealth{Name: instance.Type().Elem().String(), Status: HealthStatusUp}
+	start := time.Now()
+	result := make(chan error, 1)
+
+	go func() {
+		result <- instance.Interface().(HealthChecker).Health(ctx)
+	}()
+
+	var err error
+
+	select {
+	case err = <-result:
+	case <-ctx.Done():
+		// the check is abandoned
+		err = ctx.Err()
+	}
+
+	component.Duration = time.Since(start)
+
+	if err != nil {
+		component.Status = HealthStatusDown
+		component.Error = err.Error()
+	}
+
+	return component
+}
+
+func HealthHandler(c *Container) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := c.Health(r.Context())
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if report.Status != HealthStatusUp {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+
+		_ = json.NewEncoder(w).Encode(report)
+	})
+}
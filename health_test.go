@@ -0,0 +1,99 @@
+package di
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type HealthyThing struct{}
+
+func (h *HealthyThing) Health(_ context.Context) error {
+	return nil
+}
+
+type UnhealthyThing struct {
+	Healthy *HealthyThing
+}
+
+func (u *UnhealthyThing) Health(_ context.Context) error {
+	return errors.New("unhealthy")
+}
+
+type HangingThing struct{}
+
+func (h *HangingThing) Health(ctx context.Context) error {
+	<-ctx.Done()
+	time.Sleep(time.Second)
+	return nil
+}
+
+func TestHealth(t *testing.T) {
+	resetContainer()
+
+	BindAuto[HealthyThing]()
+	BindAuto[UnhealthyThing]()
+	BindAuto[HangingThing]()
+
+	Instance[HealthyThing]()
+
+	if report := Health(context.Background()); report.Status != HealthStatusUp || len(report.Components) != 1 {
+		t.Error("Only built instances should be checked", report)
+	}
+
+	GetContainer().SetHealthTimeout(10 * time.Millisecond)
+	Instance[UnhealthyThing]()
+	Instance[HangingThing]()
+
+	report := Health(context.Background())
+
+	if report.Status != HealthStatusDown || len(report.Components) != 3 {
+		t.Fatal("Failed asserting report", report)
+	}
+
+	expected := map[string]string{
+		"di.HealthyThing":   "",
+		"di.UnhealthyThing": "unhealthy",
+		"di.HangingThing":   context.DeadlineExceeded.Error(),
+	}
+
+	for _, component := range report.Components {
+		if message, ok := expected[component.Name]; !ok || message != component.Error {
+			t.Error("Failed asserting component", component)
+		}
+	}
+}
+
+func TestHealthHandler(t *testing.T) {
+	resetContainer()
+
+	BindAuto[HealthyThing]()
+	Instance[HealthyThing]()
+
+	recorder := httptest.NewRecorder()
+	HealthHandler(GetContainer()).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	var report HealthReport
+
+	if err := json.Unmarshal(recorder.Body.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+
+	if recorder.Code != http.StatusOK || report.Status != HealthStatusUp || report.Components[0].Name != "di.HealthyThing" {
+		t.Error("Failed asserting response", recorder.Code, recorder.Body.String())
+	}
+
+	BindAuto[UnhealthyThing]()
+	Instance[UnhealthyThing]()
+
+	recorder = httptest.NewRecorder()
+	HealthHandler(GetContainer()).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	if recorder.Code != http.StatusServiceUnavailable {
+		t.Error("Expected 503 when a component is down", recorder.Code)
+	}
+}
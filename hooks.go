@@ -0,0 +1,113 @@
+package di
+
+import (
+	"reflect"
+	"time"
+)
+
+type BindEvent struct {
+	Id     Id
+	Kind   string
+	Rule   Rule
+	Source Source
+}
+
+type ResolveEvent struct {
+	Id       Id
+	Type     reflect.Type
+	Kind     string
+	Duration time.Duration
+	Err      error
+}
+
+type BuildEvent struct {
+	Type     reflect.Type
+	Duration time.Duration
+	Err      error
+}
+
+type InitializeEvent struct {
+	Type     reflect.Type
+	Duration time.Duration
+}
+
+type CloseEvent struct {
+	Cleanups int
+	Duration time.Duration
+}
+
+// Hooks are invoked synchronously, so they must be fast and must not bind rules.
+// Nil hooks are ignored.
+type Hooks struct {
+	OnBind        func(event BindEvent)
+	BeforeResolve func(event ResolveEvent)
+	AfterResolve  func(event ResolveEvent)
+	OnBuild       func(event BuildEvent)
+	OnInitialize  func(event InitializeEvent)
+	OnClose       func(event CloseEvent)
+}
+
+func (c *Container) AddHooks(hooks Hooks) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// copied on write, so that resolution reads them without locking
+	current, _ := c.hooks.Load().([]Hooks)
+	c.hooks.Store(append(current[:len(current):len(current)], hooks))
+}
+
+func (c *Container) eachHooks(callback func(hooks *Hooks)) {
+	hooks, _ := c.hooks.Load().([]Hooks)
+
+	for i := range hooks {
+		callback(&hooks[i])
+	}
+}
+
+func (c *Container) onBind(event BindEvent) {
+	c.eachHooks(func(hooks *Hooks) {
+		if hooks.OnBind != nil {
+			hooks.OnBind(event)
+		}
+	})
+}
+
+func (c *Container) beforeResolve(event ResolveEvent) {
+	c.eachHooks(func(hooks *Hooks) {
+		if hooks.BeforeResolve != nil {
+			hooks.BeforeResolve(event)
+		}
+	})
+}
+
+func (c *Container) afterResolve(event ResolveEvent) {
+	c.eachHooks(func(hooks *Hooks) {
+		if hooks.AfterResolve != nil {
+			hooks.AfterResolve(event)
+		}
+	})
+}
+
+func (c *Container) onBuild(event BuildEvent) {
+	c.eachHooks(func(hooks *Hooks) {
+		if hooks.OnBuild != nil {
+			hooks.OnBuild(event)
+		}
+	})
+}
+
+func (c *Container) onInitialize(event InitializeEvent) {
+	c.eachHooks(func(hooks *Hooks) {
+		if hooks.OnInitialize != nil {
+			hooks.OnInitialize(event)
+		}
+	})
+}
+
+func (c *Container) onClose(event CloseEvent) {
+	c.eachHooks(func(hooks *Hooks) {
+		if hooks.OnClose != nil {
+			hooks.OnClose(event)
+		}
+	})
+}
@@ -0,0 +1,93 @@
+package di
+
+import (
+	"errors"
+	"testing"
+)
+
+type InitializedThing struct {
+	Thing1p *Thing1
+}
+
+func (i *InitializedThing) Initialize() {}
+
+func TestHooks(t *testing.T) {
+	resetContainer()
+
+	var events []string
+	var resolved []ResolveEvent
+
+	AddHooks(Hooks{
+		OnBind: func(event BindEvent) {
+			events = append(events, "bind "+string(event.Id)+" "+event.Kind)
+		},
+		BeforeResolve: func(event ResolveEvent) {
+			events = append(events, "before "+string(event.Id))
+		},
+		AfterResolve: func(event ResolveEvent) {
+			events = append(events, "after "+string(event.Id))
+			resolved = append(resolved, event)
+		},
+		OnBuild: func(event BuildEvent) {
+			events = append(events, "build "+event.Type.String())
+		},
+		OnInitialize: func(event InitializeEvent) {
+			events = append(events, "initialize "+event.Type.String())
+		},
+		OnClose: func(event CloseEvent) {
+			events = append(events, "close")
+		},
+	})
+
+	BindInstance(&Thing1{})
+	BindAuto[InitializedThing]()
+	Instance[InitializedThing]()
+	Close()
+
+	expected := []string{
+		"bind di.Thing1 instance",
+		"bind di.InitializedThing auto",
+		"before di.InitializedThing",
+		"before di.Thing1",
+		"after di.Thing1",
+		"initialize *di.InitializedThing",
+		"build di.InitializedThing",
+		"after di.InitializedThing",
+		"close",
+	}
+
+	if len(events) != len(expected) {
+		t.Fatal("Unexpected events", events)
+	}
+
+	for i := range expected {
+		if events[i] != expected[i] {
+			t.Error("Unexpected event", events[i], "expected", expected[i])
+		}
+	}
+
+	if resolved[1].Kind != "auto" || resolved[1].Duration <= 0 || resolved[1].Err != nil {
+		t.Error("Failed asserting resolve event", resolved[1])
+	}
+}
+
+func TestHooksError(t *testing.T) {
+	resetContainer()
+
+	var resolveErr error
+
+	AddHooks(Hooks{
+		AfterResolve: func(event ResolveEvent) {
+			resolveErr = event.Err
+		},
+	})
+
+	BindFactory(func() (*Thing1, error) {
+		return nil, errors.New("failed")
+	})
+	Resolve[Thing1]()
+
+	if resolveErr == nil || resolveErr.Error() != "failed" {
+		t.Error("Errors should be passed to AfterResolve", resolveErr)
+	}
+}
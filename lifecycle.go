@@ -0,0 +1,143 @@
+package di
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"reflect"
+	"syscall"
+	"time"
+)
+
+const DefaultStopTimeout = 30 * time.Second
+
+type Starter interface {
+	Start(ctx context.Context) error
+}
+
+type Stopper interface {
+	Stop(ctx context.Context) error
+}
+
+func (c *Container) SetStopTimeout(timeout time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.stopTimeout = timeout
+}
+
+// Run builds the eager singletons and starts every built Starter in dependency order.
+// It then blocks until ctx is cancelled or SIGINT/SIGTERM is received, and stops the
+// started components and the other built Stoppers in reverse order before closing the
+// container.
+func (c *Container) Run(ctx context.Context) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := c.Start(ctx); err != nil {
+		c.Close()
+		return err
+	}
+
+	started, err := c.startComponents(ctx)
+
+	if err != nil {
+		// roll back what was started before the failure
+		stopErr := c.stopComponents(started)
+		c.Close()
+
+		return errors.Join(err, stopErr)
+	}
+
+	c.log(LogLevelNotice, "started", slog.Int("components", len(started)))
+
+	<-ctx.Done()
+
+	c.log(LogLevelNotice, "stopping")
+
+	err = c.stopComponents(c.stoppable(started))
+	c.Close()
+
+	return err
+}
+
+func (c *Container) startComponents(ctx context.Context) ([]reflect.Value, error) {
+	var started []reflect.Value
+
+	// Starting a component may resolve new ones, which are started after it
+	for i := 0; ; i++ {
+		built := c.builtInstances()
+
+		if i >= len(built) {
+			return started, nil
+		}
+
+		instance := built[i]
+
+		if starter, ok := instance.Interface().(Starter); ok {
+			c.log(LogLevelTrace, "starting", slog.String("type", instance.Type().String()))
+
+			if err := starter.Start(ctx); err != nil {
+				return started, fmt.Errorf("could not start %s: %w", instance.Type(), err)
+			}
+		}
+
+		started = append(started, instance)
+	}
+}
+
+// stoppable returns the built components that were started, or that are not Starters,
+// as Starters resolved after startup were never started
+func (c *Container) stoppable(started []reflect.Value) []reflect.Value {
+	startedSet := make(map[builtKey]bool, len(started))
+
+	for _, instance := range started {
+		startedSet[builtKey{instance.Type(), instance.Pointer()}] = true
+	}
+
+	var components []reflect.Value
+
+	for _, instance := range c.builtInstances() {
+		_, isStarter := instance.Interface().(Starter)
+
+		if !isStarter || startedSet[builtKey{instance.Type(), instance.Pointer()}] {
+			components = append(components, instance)
+		}
+	}
+
+	return components
+}
+
+func (c *Container) stopComponents(components []reflect.Value) error {
+	c.mutex.Lock()
+	timeout := c.stopTimeout
+	c.mutex.Unlock()
+
+	if timeout <= 0 {
+		timeout = DefaultStopTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var errs []error
+
+	for i := len(components) - 1; i >= 0; i-- {
+		stopper, ok := components[i].Interface().(Stopper)
+
+		if !ok {
+			continue
+		}
+
+		c.log(LogLevelTrace, "stopping", slog.String("type", components[i].Type().String()))
+
+		if err := stopper.Stop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("could not stop %s: %w", components[i].Type(), err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
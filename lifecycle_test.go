@@ -0,0 +1,210 @@
+package di
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+var lifecycleEvents []string
+
+// runStarted is closed by the last component started in a test
+var runStarted chan struct{}
+
+func signalStarted() {
+	if runStarted != nil {
+		close(runStarted)
+		runStarted = nil
+	}
+}
+
+type Worker struct {
+	name string
+	fail bool
+}
+
+func (w *Worker) Start(_ context.Context) error {
+	if w.fail {
+		return errors.New(w.name + " failed")
+	}
+
+	lifecycleEvents = append(lifecycleEvents, "start "+w.name)
+	return nil
+}
+
+func (w *Worker) Stop(_ context.Context) error {
+	lifecycleEvents = append(lifecycleEvents, "stop "+w.name)
+	return nil
+}
+
+type Server struct {
+	Worker *Worker
+}
+
+func (s *Server) Start(_ context.Context) error {
+	lifecycleEvents = append(lifecycleEvents, "start server")
+	signalStarted()
+	return nil
+}
+
+func (s *Server) Stop(_ context.Context) error {
+	lifecycleEvents = append(lifecycleEvents, "stop server")
+	return nil
+}
+
+type FailingWorker struct {
+	Worker
+}
+
+type LazyWorker struct {
+	Worker
+}
+
+type SlowStopper struct{}
+
+func (s *SlowStopper) Stop(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+// zero-size values may share their address
+type ZeroStarterA struct{}
+
+func (s *ZeroStarterA) Start(_ context.Context) error {
+	lifecycleEvents = append(lifecycleEvents, "start a")
+	return nil
+}
+
+func (s *ZeroStarterA) Stop(_ context.Context) error {
+	lifecycleEvents = append(lifecycleEvents, "stop a")
+	return nil
+}
+
+type ZeroStarterB struct{}
+
+func (s *ZeroStarterB) Inject(_ *ZeroStarterA) {}
+
+func (s *ZeroStarterB) Start(_ context.Context) error {
+	lifecycleEvents = append(lifecycleEvents, "start b")
+	signalStarted()
+	return nil
+}
+
+func (s *ZeroStarterB) Stop(_ context.Context) error {
+	lifecycleEvents = append(lifecycleEvents, "stop b")
+	return nil
+}
+
+func TestRun(t *testing.T) {
+	resetContainer()
+	lifecycleEvents = nil
+
+	started := make(chan struct{})
+	runStarted = started
+
+	BindInstance(&Worker{name: "worker"})
+	BindAuto[Server](Eager())
+	BindProvider(func() (*LazyWorker, error) {
+		return &LazyWorker{Worker{name: "lazy"}}, nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+
+	go func() {
+		done <- Run(ctx)
+	}()
+
+	<-started
+	// resolved after startup, so it is never started nor stopped
+	Instance[LazyWorker]()
+	cancel()
+
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "start worker,start server,stop server,stop worker"
+
+	if strings.Join(lifecycleEvents, ",") != expected {
+		t.Error("Components should be started in dependency order and stopped in reverse", lifecycleEvents)
+	}
+}
+
+func TestRunRollback(t *testing.T) {
+	resetContainer()
+	lifecycleEvents = nil
+
+	BindInstance(&Worker{name: "worker"})
+	BindAuto[Server]()
+	BindConstructor(func() (*Thing1, func(), error) {
+		return &Thing1{}, func() { lifecycleEvents = append(lifecycleEvents, "cleanup") }, nil
+	})
+	BindProvider(func(server *Server, _ *Thing1) (*Thing1Alt, error) {
+		return &Thing1Alt{}, nil
+	}, Eager())
+	BindProvider(func(_ *Thing1Alt) (*FailingWorker, error) {
+		return &FailingWorker{Worker{name: "failing", fail: true}}, nil
+	}, Eager())
+
+	err := Run(context.Background())
+
+	if err == nil || !strings.Contains(err.Error(), "failing failed") {
+		t.Fatal("Expected start error", err)
+	}
+
+	expected := "start worker,start server,stop server,stop worker,cleanup"
+
+	if strings.Join(lifecycleEvents, ",") != expected {
+		t.Error("Started components should be rolled back", lifecycleEvents)
+	}
+}
+
+func TestStopTimeout(t *testing.T) {
+	resetContainer()
+
+	GetContainer().SetStopTimeout(10 * time.Millisecond)
+	BindAuto[SlowStopper](Eager())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := Run(ctx)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("Expected the stop timeout to be exceeded", err)
+	}
+}
+
+func TestRunZeroSize(t *testing.T) {
+	resetContainer()
+	lifecycleEvents = nil
+
+	BindAuto[ZeroStarterA]()
+	BindAuto[ZeroStarterB](Eager())
+
+	started := make(chan struct{})
+	runStarted = started
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+
+	go func() {
+		done <- Run(ctx)
+	}()
+
+	<-started
+	cancel()
+
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "start a,start b,stop b,stop a"
+
+	if strings.Join(lifecycleEvents, ",") != expected {
+		t.Error("Zero-size components should be tracked separately", lifecycleEvents)
+	}
+}
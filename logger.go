@@ -0,0 +1,113 @@
+package di
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"reflect"
+	"strings"
+	"time"
+)
+
+type Logger interface {
+	Enabled(level slog.Level) bool
+	Log(level slog.Level, msg string, attrs ...slog.Attr)
+}
+
+type slogLogger struct {
+	handler slog.Handler
+}
+
+func NewSlogLogger(handler slog.Handler) Logger {
+	return &slogLogger{handler}
+}
+
+func (l *slogLogger) Enabled(level slog.Level) bool {
+	return l.handler.Enabled(context.Background(), level)
+}
+
+func (l *slogLogger) Log(level slog.Level, msg string, attrs ...slog.Attr) {
+	record := slog.NewRecord(time.Now(), level, msg, 0)
+	record.AddAttrs(attrs...)
+	_ = l.handler.Handle(context.Background(), record)
+}
+
+type stdLogger struct {
+	logger *log.Logger
+}
+
+func NewStdLogger(logger *log.Logger) Logger {
+	return &stdLogger{logger}
+}
+
+func (l *stdLogger) Enabled(_ slog.Level) bool {
+	return true
+}
+
+func (l *stdLogger) Log(level slog.Level, msg string, attrs ...slog.Attr) {
+	var builder strings.Builder
+
+	switch {
+	case level >= slog.LevelError:
+		builder.WriteString("ERROR: ")
+	case level >= slog.LevelWarn:
+		builder.WriteString("WARNING: ")
+	}
+
+	builder.WriteString(msg)
+
+	for _, attr := range attrs {
+		fmt.Fprintf(&builder, " %s=%s", attr.Key, attr.Value)
+	}
+
+	l.logger.Print(builder.String())
+}
+
+func toSlogLevel(logLevel int) slog.Level {
+	switch {
+	case hasLogLevel(logLevel, LogLevelError):
+		return slog.LevelError
+	case hasLogLevel(logLevel, LogLevelWarning):
+		return slog.LevelWarn
+	case hasLogLevel(logLevel, LogLevelNotice):
+		return slog.LevelInfo
+	}
+
+	return slog.LevelDebug
+}
+
+func (c *Container) logEnabled(logLevel int) bool {
+	return c.logger != nil && hasLogLevel(c.logLevel, logLevel) && c.logger.Enabled(toSlogLevel(logLevel))
+}
+
+func (c *Container) log(logLevel int, msg string, attrs ...slog.Attr) {
+	if c.logEnabled(logLevel) {
+		c.logger.Log(toSlogLevel(logLevel), msg, attrs...)
+	}
+}
+
+func ruleKind(rule Rule) string {
+	switch rule.(type) {
+	case *typeRule:
+		return "type"
+	case *instanceRule:
+		return "instance"
+	case *genericRule:
+		return "generic"
+	case *autoRule:
+		return "auto"
+	case *providerRule:
+		return "provider"
+	case *factoryRule:
+		return "factory"
+	case *constructorRule:
+		return "constructor"
+	case *valueRule:
+		return "value"
+	case nil:
+		return "none"
+	}
+
+	return reflect.TypeOf(rule).String()
+}
@@ -0,0 +1,93 @@
+package di
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+type PrivateMember struct {
+	thing1 *Thing1
+}
+
+func TestLogLevelDefault(t *testing.T) {
+	if LogLevelDefault == LogLevelNone || hasLogLevel(LogLevelDefault, LogLevelTrace) {
+		t.Error("LogLevelDefault should include errors, warnings and notices only")
+	}
+}
+
+func TestStdLogger(t *testing.T) {
+	Reset()
+
+	var buffer bytes.Buffer
+	SetLogger(log.New(&buffer, "DI: ", 0))
+
+	BindInstance(&Thing1{})
+	BindAuto[PrivateMember]()
+	Instance[PrivateMember]()
+
+	expected := "DI: WARNING: can't set private member"
+
+	if !strings.HasPrefix(buffer.String(), expected) || !strings.Contains(buffer.String(), "field=thing1") {
+		t.Error("Expected a warning with its attributes", buffer.String())
+	}
+
+	if strings.Contains(buffer.String(), "resolving") {
+		t.Error("Trace messages should not be logged by default", buffer.String())
+	}
+}
+
+func TestSlogLogger(t *testing.T) {
+	Reset()
+
+	var buffer bytes.Buffer
+	SetLogHandler(slog.NewJSONHandler(&buffer, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	SetLogLevel(LogLevelAll)
+
+	BindAuto[Thing1]()
+	Instance[Thing1]()
+
+	var resolved map[string]any
+
+	for _, line := range strings.Split(strings.TrimSpace(buffer.String()), "\n") {
+		var entry map[string]any
+
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatal(err)
+		}
+
+		if entry["msg"] == "resolved" {
+			resolved = entry
+		}
+	}
+
+	if resolved == nil {
+		t.Fatal("Expected a resolved event", buffer.String())
+	}
+
+	if resolved["level"] != "DEBUG" || resolved["type"] != "di.Thing1" || resolved["kind"] != "auto" {
+		t.Error("Failed asserting attributes", resolved)
+	}
+
+	if _, ok := resolved["duration"]; !ok {
+		t.Error("Expected a duration", resolved)
+	}
+}
+
+func TestSlogLoggerLevel(t *testing.T) {
+	Reset()
+
+	var buffer bytes.Buffer
+	SetLogHandler(slog.NewTextHandler(&buffer, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	SetLogLevel(LogLevelAll)
+
+	BindAuto[Thing1]()
+	Instance[Thing1]()
+
+	if buffer.Len() != 0 {
+		t.Error("The handler's level should be respected", buffer.String())
+	}
+}
@@ -0,0 +1,297 @@
+package di
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+)
+
+// Binder is where bindings are set. It is also a BindOption, so that the Bind functions
+// target it instead of the current container, e.g. `di.BindAuto[T](b)`.
+type Binder interface {
+	BindOption
+	Bind(key Id, rule Rule, options ...BindOption)
+	HasRule(key Id) bool
+	Container() *Container
+}
+
+func (c *Container) applyBind(_ *binding) {}
+
+func (c *Container) Container() *Container {
+	return c
+}
+
+func binderOf(options []BindOption) Binder {
+	for i := len(options) - 1; i >= 0; i-- {
+		if binder, ok := options[i].(Binder); ok {
+			return binder
+		}
+	}
+
+	return GetContainer()
+}
+
+type Module interface {
+	Name() string
+	Configure(b Binder)
+}
+
+// ModuleImports is implemented by modules that depend on other modules, which are
+// installed before them.
+type ModuleImports interface {
+	Imports() []Module
+}
+
+// ModuleExports is implemented by modules that restrict which of their bindings can be
+// resolved from outside the module, when the container enforces exports.
+type ModuleExports interface {
+	Exports() []Id
+}
+
+type module struct {
+	name      string
+	configure func(b Binder)
+	imports   []Module
+	exports   []Id
+}
+
+type ModuleOption func(m *module)
+
+func Imports(modules ...Module) ModuleOption {
+	return func(m *module) {
+		m.imports = append(m.imports, modules...)
+	}
+}
+
+func Exports(ids ...Id) ModuleOption {
+	return func(m *module) {
+		if m.exports == nil {
+			m.exports = []Id{}
+		}
+
+		m.exports = append(m.exports, ids...)
+	}
+}
+
+func NewModule(name string, configure func(b Binder), options ...ModuleOption) Module {
+	m := &module{name: name, configure: configure}
+
+	for _, option := range options {
+		option(m)
+	}
+
+	return m
+}
+
+func (m *module) Name() string {
+	return m.name
+}
+
+func (m *module) Configure(b Binder) {
+	m.configure(b)
+}
+
+func (m *module) Imports() []Module {
+	return m.imports
+}
+
+func (m *module) Exports() []Id {
+	return m.exports
+}
+
+type moduleBinder struct {
+	container *Container
+	name      string
+	exports   map[Id]bool
+	errs      []error
+}
+
+func (b *moduleBinder) applyBind(_ *binding) {}
+
+func (b *moduleBinder) Bind(key Id, rule Rule, options ...BindOption) {
+	exported := b.exports == nil || b.exports[key]
+
+	options = append(options[:len(options):len(options)], bindOptionFunc(func(binding *binding) {
+		binding.module = b.name
+		binding.exported = exported
+	}))
+
+	if err := b.container.bind(key, rule, options...); err != nil {
+		b.errs = append(b.errs, err)
+	}
+}
+
+func (b *moduleBinder) HasRule(key Id) bool {
+	return b.container.HasRule(key)
+}
+
+func (b *moduleBinder) Container() *Container {
+	return b.container
+}
+
+// SetEnforceExports makes bindings that are not exported by their module resolvable only
+// by other bindings of the same module.
+func (c *Container) SetEnforceExports(enforce bool) {
+	c.enforceExports.Store(enforce)
+}
+
+type overrideModule struct {
+	Module
+}
+
+// Override marks the bindings of a module as replacing existing ones. Overrides are
+// installed after the other modules given to Install, and are kept when an id is bound
+// again without Override.
+func Override(m Module) Module {
+	return &overrideModule{m}
+}
+
+func (m *overrideModule) Configure(b Binder) {
+	m.Module.Configure(&overrideBinder{b})
+}
+
+func (m *overrideModule) Imports() []Module {
+	if importer, ok := m.Module.(ModuleImports); ok {
+		return importer.Imports()
+	}
+
+	return nil
+}
+
+func (m *overrideModule) Exports() []Id {
+	if exporter, ok := m.Module.(ModuleExports); ok {
+		return exporter.Exports()
+	}
+
+	return nil
+}
+
+type overrideBinder struct {
+	Binder
+}
+
+func (b *overrideBinder) Bind(key Id, rule Rule, options ...BindOption) {
+	options = append(options[:len(options):len(options)], bindOptionFunc(func(binding *binding) {
+		binding.override = true
+	}))
+
+	b.Binder.Bind(key, rule, options...)
+}
+
+// Install configures modules, after the modules they import. Installing a module twice
+// is an error, while modules imported by several modules are only installed once.
+// Rebinding an id bound by another module is an error in strict binding mode, unless
+// the module is wrapped by Override.
+func (c *Container) Install(modules ...Module) error {
+	if c.IsFrozen() {
+		return errors.New("can't install modules, the container is frozen")
+	}
+
+	ordered := make([]Module, 0, len(modules))
+
+	for _, m := range modules {
+		if _, ok := m.(*overrideModule); !ok {
+			ordered = append(ordered, m)
+		}
+	}
+
+	for _, m := range modules {
+		if _, ok := m.(*overrideModule); ok {
+			ordered = append(ordered, m)
+		}
+	}
+
+	for _, m := range ordered {
+		if c.isInstalled(m.Name()) {
+			return fmt.Errorf("module %s is already installed", m.Name())
+		}
+
+		if err := c.install(m, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Container) isInstalled(name string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.modules[name]
+}
+
+func (c *Container) install(m Module, path []string) error {
+	for _, name := range path {
+		if name == m.Name() {
+			return fmt.Errorf("circular module imports: %v", append(path, m.Name()))
+		}
+	}
+
+	if c.isInstalled(m.Name()) {
+		return nil
+	}
+
+	if importer, ok := m.(ModuleImports); ok {
+		for _, imported := range importer.Imports() {
+			if err := c.install(imported, append(path, m.Name())); err != nil {
+				return err
+			}
+		}
+	}
+
+	binder := &moduleBinder{container: c, name: m.Name()}
+
+	if exporter, ok := m.(ModuleExports); ok && exporter.Exports() != nil {
+		binder.exports = make(map[Id]bool)
+
+		for _, id := range exporter.Exports() {
+			binder.exports[id] = true
+		}
+	}
+
+	c.log(LogLevelTrace, "installing module", slog.String("module", m.Name()))
+
+	c.mutex.Lock()
+	c.modules[m.Name()] = true
+	c.mutex.Unlock()
+
+	m.Configure(binder)
+
+	return errors.Join(binder.errs...)
+}
+
+// Modules returns the names of the installed modules.
+func (c *Container) Modules() []string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	names := make([]string, 0, len(c.modules))
+
+	for name := range c.modules {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func (c *Container) checkExported(typeId Id, b *binding) error {
+	if b.module == "" || b.exported {
+		return nil
+	}
+
+	if !c.enforceExports.Load() {
+		return nil
+	}
+
+	if c.frame != nil {
+		if requester := c.getBinding(c.frame.id); requester != nil && requester.module == b.module {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s is not exported by %s", typeId, b.site())
+}
@@ -0,0 +1,211 @@
+package di
+
+import (
+	"bytes"
+	"log"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+type ModuleStore struct{}
+
+type ModuleRepository interface {
+	Store() *ModuleStore
+}
+
+type ModuleRepositoryImpl struct {
+	store *ModuleStore
+}
+
+func (r *ModuleRepositoryImpl) Inject(store *ModuleStore) {
+	r.store = store
+}
+
+func (r *ModuleRepositoryImpl) Store() *ModuleStore {
+	return r.store
+}
+
+type ModuleHandler struct {
+	Repository ModuleRepository
+}
+
+func newStorageModule() Module {
+	return NewModule("storage", func(b Binder) {
+		BindAuto[ModuleStore](b)
+		BindType[ModuleRepository, ModuleRepositoryImpl](b)
+	}, Exports(TypeId[ModuleRepository]()))
+}
+
+func TestInstall(t *testing.T) {
+	resetContainer()
+
+	storage := newStorageModule()
+	api := NewModule("api", func(b Binder) {
+		BindAuto[ModuleHandler](b)
+	}, Imports(storage))
+
+	if err := Install(api, NewModule("other", func(b Binder) {}, Imports(storage))); err != nil {
+		t.Fatal(err)
+	}
+
+	if modules := GetContainer().Modules(); !reflect.DeepEqual(modules, []string{"api", "other", "storage"}) {
+		t.Error("Failed asserting installed modules", modules)
+	}
+
+	if handler := Instance[ModuleHandler](); handler.Repository.Store() == nil {
+		t.Error("Failed asserting module bindings")
+	}
+
+	if err := Install(storage); err == nil || !strings.Contains(err.Error(), "module storage is already installed") {
+		t.Error("Failed asserting duplicate install error", err)
+	}
+}
+
+func TestInstallCircularImports(t *testing.T) {
+	resetContainer()
+
+	a := &module{name: "a", configure: func(b Binder) {}}
+	b := &module{name: "b", configure: func(b Binder) {}, imports: []Module{a}}
+	a.imports = []Module{b}
+
+	if err := Install(a); err == nil || !strings.Contains(err.Error(), "circular module imports") {
+		t.Error("Failed asserting circular imports error", err)
+	}
+}
+
+func TestSetEnforceExports(t *testing.T) {
+	resetContainer()
+
+	if err := Install(newStorageModule()); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Resolve[ModuleStore](); err != nil {
+		t.Error("Exports should not be enforced by default", err)
+	}
+
+	GetContainer().SetEnforceExports(true)
+
+	if _, err := Resolve[ModuleStore](); err == nil || !strings.Contains(err.Error(), "di.ModuleStore is not exported by module storage") {
+		t.Error("Failed asserting export error", err)
+	}
+
+	repository, err := ResolveImpl[ModuleRepository]()
+
+	if err != nil || repository.Store() == nil {
+		t.Error("Exported bindings should resolve their module dependencies", err)
+	}
+
+	BindAuto[ModuleHandler]()
+
+	if _, err := Resolve[ModuleHandler](); err != nil {
+		t.Error("Failed asserting exported dependency", err)
+	}
+}
+
+func TestModuleConfig(t *testing.T) {
+	resetContainer()
+
+	config := NewModule("config", func(b Binder) {
+		BindConfig[FileConfig]([]ConfigSource{Overrides(map[string]string{"MODE": "module", "DB_PASSWORD": "secret"})}, b)
+		BindWatched[FileDB](nil, b)
+	})
+
+	if err := Install(config); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, info := range Bindings() {
+		if info.Module != "config" {
+			t.Error("Failed asserting module binding", info.Id)
+		}
+	}
+
+	if bindings := Bindings(); len(bindings) != 3 {
+		t.Error("Failed asserting config bindings", bindings)
+	}
+
+	if Instance[FileConfig]().Mode != "module" {
+		t.Error("Bind options should not be loaded as config sources")
+	}
+}
+
+type FakeModuleRepository struct{}
+
+func (r *FakeModuleRepository) Store() *ModuleStore {
+	return nil
+}
+
+func newFakeStorageModule() Module {
+	return NewModule("fake-storage", func(b Binder) {
+		BindType[ModuleRepository, FakeModuleRepository](b)
+	})
+}
+
+func TestOverride(t *testing.T) {
+	resetContainer()
+	GetContainer().SetStrictBinding(true)
+
+	if err := Install(Override(newFakeStorageModule()), newStorageModule()); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := Impl[ModuleRepository]().(*FakeModuleRepository); !ok {
+		t.Error("Failed asserting override")
+	}
+
+	assertPanics(t, "di.ModuleRepository is overridden by module fake-storage", func() {
+		BindType[ModuleRepository, ModuleRepositoryImpl]()
+	})
+
+	var buffer bytes.Buffer
+	GetContainer().SetLogger(log.New(&buffer, "", 0))
+	GetContainer().SetStrictBinding(false)
+
+	BindType[ModuleRepository, ModuleRepositoryImpl]()
+
+	if _, ok := Impl[ModuleRepository]().(*FakeModuleRepository); !ok {
+		t.Error("Overrides should be kept when rebinding")
+	}
+
+	if !regexp.MustCompile(`WARNING: keeping override .* is overridden by module fake-storage \(.*module_test.go:\d+\), ignoring the binding by .*module_test.go:\d+`).MatchString(buffer.String()) {
+		t.Error("Failed asserting override warning", buffer.String())
+	}
+}
+
+func TestStrictBinding(t *testing.T) {
+	resetContainer()
+	GetContainer().SetStrictBinding(true)
+
+	err := Install(newStorageModule(), NewModule("duplicate", func(b Binder) {
+		BindType[ModuleRepository, FakeModuleRepository](b)
+	}))
+
+	if err == nil || !regexp.MustCompile(`di.ModuleRepository is bound by module storage \(.*module_test.go:\d+\) and rebound by module duplicate \(.*module_test.go:\d+\)`).MatchString(err.Error()) {
+		t.Error("Failed asserting rebinding error", err)
+	}
+
+	defer func() {
+		if r := recover(); r == nil || !regexp.MustCompile(`is bound by module storage \(.*\) and rebound by .*module_test.go:\d+,`).MatchString(r.(string)) {
+			t.Error("Failed asserting rebinding panic", r)
+		}
+	}()
+
+	BindAuto[ModuleStore]()
+}
+
+func TestRebindingWarning(t *testing.T) {
+	resetContainer()
+
+	var buffer bytes.Buffer
+	GetContainer().SetLogger(log.New(&buffer, "", 0))
+
+	BindAuto[ModuleStore]()
+	BindAuto[ModuleStore]()
+
+	if !regexp.MustCompile(`WARNING: rebinding .* is bound by .*module_test.go:\d+ and rebound by .*module_test.go:\d+,`).MatchString(buffer.String()) {
+		t.Error("Failed asserting rebinding warning", buffer.String())
+	}
+}
@@ -0,0 +1,33 @@
+package di
+
+type BindOption interface {
+	applyBind(b *binding)
+}
+
+type bindOptionFunc func(b *binding)
+
+func (f bindOptionFunc) applyBind(b *binding) {
+	f(b)
+}
+
+// Default makes a binding used only when the id has no regular binding.
+func Default() BindOption {
+	return bindOptionFunc(func(b *binding) {
+		b.fallback = true
+	})
+}
+
+// Priority ranks the alternatives of an id, the highest active one is used. Bindings
+// have priority 0 by default.
+func Priority(priority int) BindOption {
+	return bindOptionFunc(func(b *binding) {
+		b.priority = priority
+	})
+}
+
+// Eager marks a singleton to be built by Container.Start() instead of on first use.
+func Eager() BindOption {
+	return bindOptionFunc(func(b *binding) {
+		b.eager = true
+	})
+}
@@ -0,0 +1,220 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unsafe"
+)
+
+const redacted = "[REDACTED]"
+
+const maxRedactDepth = 8
+
+type Redactor interface {
+	Redact() string
+}
+
+// Redact formats value like the %+v verb, hiding members tagged with `di:"secret"`
+// and using Redact() for values implementing Redactor.
+func Redact(value any) string {
+	v := reflect.ValueOf(value)
+
+	if !v.IsValid() {
+		return "<nil>"
+	}
+
+	if !v.CanAddr() {
+		addressable := reflect.New(v.Type()).Elem()
+		addressable.Set(v)
+		v = addressable
+	}
+
+	var builder strings.Builder
+	writeRedacted(&builder, v, 0, make(map[uintptr]bool))
+
+	return builder.String()
+}
+
+func writeRedacted(builder *strings.Builder, v reflect.Value, depth int, visited map[uintptr]bool) {
+	if !v.IsValid() {
+		builder.WriteString("<invalid>")
+		return
+	}
+
+	v = interfaceable(v)
+
+	if v.CanInterface() {
+		if redactor, ok := v.Interface().(Redactor); ok && (v.Kind() != reflect.Pointer || !v.IsNil()) {
+			builder.WriteString(redactor.Redact())
+			return
+		}
+
+		if v.Kind() != reflect.Pointer && v.CanAddr() {
+			if redactor, ok := v.Addr().Interface().(Redactor); ok {
+				builder.WriteString(redactor.Redact())
+				return
+			}
+		}
+
+		if typeInfo, ok := v.Interface().(reflect.Type); ok && typeInfo != nil {
+			builder.WriteString(typeInfo.String())
+			return
+		}
+
+		if inner, ok := v.Interface().(reflect.Value); ok {
+			writeRedacted(builder, inner, depth, visited)
+			return
+		}
+	}
+
+	if depth > maxRedactDepth {
+		builder.WriteString("...")
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			builder.WriteString("<nil>")
+			return
+		}
+
+		if visited[v.Pointer()] {
+			fmt.Fprintf(builder, "%#x", v.Pointer())
+			return
+		}
+
+		visited[v.Pointer()] = true
+		defer delete(visited, v.Pointer())
+
+		builder.WriteString("&")
+		writeRedacted(builder, v.Elem(), depth+1, visited)
+	case reflect.Interface:
+		if v.IsNil() {
+			builder.WriteString("<nil>")
+			return
+		}
+
+		writeRedacted(builder, v.Elem(), depth, visited)
+	case reflect.Struct:
+		builder.WriteString("{")
+
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+
+			if i > 0 {
+				builder.WriteString(" ")
+			}
+
+			builder.WriteString(field.Name + ":")
+
+			if isSecret(field) {
+				builder.WriteString(redacted)
+				continue
+			}
+
+			writeRedacted(builder, v.Field(i), depth+1, visited)
+		}
+
+		builder.WriteString("}")
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			builder.WriteString("[]")
+			return
+		}
+
+		builder.WriteString("[")
+
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				builder.WriteString(" ")
+			}
+
+			writeRedacted(builder, v.Index(i), depth+1, visited)
+		}
+
+		builder.WriteString("]")
+	case reflect.Map:
+		keys := v.MapKeys()
+		formattedKeys := make([]string, len(keys))
+
+		for i, key := range keys {
+			var keyBuilder strings.Builder
+			writeRedacted(&keyBuilder, key, depth+1, visited)
+			formattedKeys[i] = keyBuilder.String()
+		}
+
+		order := make([]int, len(keys))
+
+		for i := range order {
+			order[i] = i
+		}
+
+		sort.Slice(order, func(i, j int) bool {
+			return formattedKeys[order[i]] < formattedKeys[order[j]]
+		})
+
+		builder.WriteString("map[")
+
+		for n, i := range order {
+			if n > 0 {
+				builder.WriteString(" ")
+			}
+
+			builder.WriteString(formattedKeys[i] + ":")
+			writeRedacted(builder, v.MapIndex(keys[i]), depth+1, visited)
+		}
+
+		builder.WriteString("]")
+	case reflect.Func:
+		if v.IsNil() {
+			builder.WriteString("<nil>")
+			return
+		}
+
+		builder.WriteString(v.Type().String())
+	case reflect.Chan, reflect.UnsafePointer:
+		fmt.Fprintf(builder, "%#x", v.Pointer())
+	case reflect.String:
+		builder.WriteString(v.String())
+	case reflect.Bool:
+		fmt.Fprint(builder, v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v.CanInterface() {
+			// e.g. time.Duration implements Stringer
+			fmt.Fprintf(builder, "%v", v.Interface())
+			return
+		}
+
+		fmt.Fprint(builder, v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		fmt.Fprint(builder, v.Uint())
+	case reflect.Float32, reflect.Float64:
+		fmt.Fprint(builder, v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		fmt.Fprint(builder, v.Complex())
+	default:
+		builder.WriteString("<" + v.Type().String() + ">")
+	}
+}
+
+func interfaceable(v reflect.Value) reflect.Value {
+	if v.CanInterface() || !v.CanAddr() {
+		return v
+	}
+
+	// unexported members are only read, so that nested secrets are still found
+	return reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem()
+}
+
+func isSecret(field reflect.StructField) bool {
+	for _, option := range strings.Split(field.Tag.Get("di"), ",") {
+		if option == "secret" {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,88 @@
+package di
+
+import (
+	"bytes"
+	"log"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type SecretConfig struct {
+	User     string
+	Password string `di:"secret"`
+	token    string `di:"secret"`
+	Nested   *SecretConfig
+	Key      APIKey
+}
+
+type APIKey string
+
+func (k APIKey) Redact() string {
+	return "key-" + strings.Repeat("*", len(k))
+}
+
+type SecretConsumer struct {
+	Config *SecretConfig
+}
+
+func TestRedact(t *testing.T) {
+	config := &SecretConfig{
+		User:     "user",
+		Password: "password",
+		token:    "t0k3n",
+		Key:      "abc",
+		Nested:   &SecretConfig{Password: "n3st3d"},
+	}
+	config.Nested.Nested = config
+
+	formatted := Redact(config)
+
+	for _, secret := range []string{"password", "t0k3n", "n3st3d", "abc"} {
+		if strings.Contains(formatted, secret) {
+			t.Error("Secret leaked in", formatted)
+		}
+	}
+
+	if !strings.Contains(formatted, "User:user") || !strings.Contains(formatted, "Key:key-***") {
+		t.Error("Failed formatting", formatted)
+	}
+
+	if Redact(map[string]int{"b": 2, "a": 1}) != "map[a:1 b:2]" {
+		t.Error("Failed formatting map", Redact(map[string]int{"b": 2, "a": 1}))
+	}
+}
+
+func TestRedactLogs(t *testing.T) {
+	Reset()
+
+	var buffer bytes.Buffer
+	SetLogger(log.New(&buffer, "", 0))
+	SetLogLevel(LogLevelAll)
+
+	BindInstance(&SecretConfig{User: "user", Password: "password"})
+	BindAuto[SecretConsumer]()
+	Instance[SecretConsumer]()
+
+	if !strings.Contains(buffer.String(), "setting child") {
+		t.Fatal("Expected trace logs", buffer.String())
+	}
+
+	if strings.Contains(buffer.String(), "password") {
+		t.Error("Secret leaked in logs", buffer.String())
+	}
+}
+
+type RedactedRule struct {
+	typeTo reflect.Type
+	value  reflect.Value
+}
+
+func TestRedactReflection(t *testing.T) {
+	secret := &SecretConfig{Password: "hunter2"}
+	redacted := Redact(&RedactedRule{typeTo: Type[SecretConfig](), value: reflect.ValueOf(secret)})
+
+	if !strings.Contains(redacted, "typeTo:di.SecretConfig") || strings.Contains(redacted, "hunter2") || strings.Contains(redacted, "ptrToThis") {
+		t.Error("Types should be printed by name, and values redacted", redacted)
+	}
+}
@@ -0,0 +1,220 @@
+package di
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	SpanBuild      = "build"
+	SpanCallback   = "callback"
+	SpanInitialize = "initialize"
+)
+
+type Span struct {
+	Kind     string
+	Name     string
+	Start    time.Time
+	Duration time.Duration
+	Children []*Span
+}
+
+// Self is the time spent in the span, excluding its children.
+func (s *Span) Self() time.Duration {
+	self := s.Duration
+
+	for _, child := range s.Children {
+		self -= child.Duration
+	}
+
+	if self < 0 {
+		return 0
+	}
+
+	return self
+}
+
+type reportRecorder struct {
+	mutex sync.Mutex
+	roots []*Span
+}
+
+// StartupReport holds the spans recorded since EnableStartupReport(). Nesting is
+// determined by which resolution caused which, so it is also exact for concurrent builds.
+type StartupReport struct {
+	Spans []*Span
+}
+
+func (c *Container) EnableStartupReport() {
+	c.report.CompareAndSwap(nil, &reportRecorder{})
+}
+
+func (c *Container) DisableStartupReport() {
+	c.report.Store(nil)
+}
+
+func (c *Container) StartupReport() *StartupReport {
+	recorder := c.report.Load()
+
+	if recorder == nil {
+		return &StartupReport{}
+	}
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	return &StartupReport{copySpans(recorder.roots)}
+}
+
+func copySpans(spans []*Span) []*Span {
+	copied := make([]*Span, len(spans))
+
+	for i, span := range spans {
+		spanCopy := *span
+		spanCopy.Children = copySpans(span.Children)
+		copied[i] = &spanCopy
+	}
+
+	return copied
+}
+
+func (c *Container) startSpan(kind string, name string) (*Container, *Span) {
+	recorder := c.report.Load()
+
+	if recorder == nil {
+		return c, nil
+	}
+
+	span := &Span{Kind: kind, Name: name, Start: time.Now()}
+
+	recorder.mutex.Lock()
+
+	if c.frame != nil && c.frame.span != nil {
+		c.frame.span.Children = append(c.frame.span.Children, span)
+	} else {
+		recorder.roots = append(recorder.roots, span)
+	}
+
+	recorder.mutex.Unlock()
+
+	frame := &resolveFrame{span: span, owner: c.owner()}
+
+	if c.frame != nil {
+		frame.id = c.frame.id
+		frame.parent = c.frame.parent
+		frame.source = c.frame.source
+	}
+
+	return &Container{c.containerState, frame}, span
+}
+
+func (c *Container) endSpan(span *Span) {
+	if span == nil {
+		return
+	}
+
+	recorder := c.report.Load()
+
+	if recorder == nil {
+		return
+	}
+
+	recorder.mutex.Lock()
+	span.Duration = time.Since(span.Start)
+	recorder.mutex.Unlock()
+}
+
+func (c *Container) callSpan(callback any) ([]reflect.Value, error) {
+	name := reflect.TypeOf(callback).String()
+
+	if funcType := reflect.TypeOf(callback); funcType.Kind() == reflect.Func && funcType.NumOut() > 0 {
+		name = funcType.Out(0).String()
+	}
+
+	callContainer, span := c.startSpan(SpanCallback, name)
+	defer c.endSpan(span)
+
+	return callContainer.Call(callback)
+}
+
+func (r *StartupReport) WriteTree(w io.Writer) error {
+	return writeSpans(w, r.Spans, 0)
+}
+
+func writeSpans(w io.Writer, spans []*Span, depth int) error {
+	sorted := make([]*Span, len(spans))
+	copy(sorted, spans)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Duration > sorted[j].Duration
+	})
+
+	for _, span := range sorted {
+		_, err := fmt.Fprintf(w, "%s%s %s %s (self %s)\n",
+			strings.Repeat("  ", depth), span.Kind, span.Name, span.Duration, span.Self())
+
+		if err != nil {
+			return err
+		}
+
+		if err := writeSpans(w, span.Children, depth+1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type chromeTraceEvent struct {
+	Name     string         `json:"name"`
+	Category string         `json:"cat"`
+	Phase    string         `json:"ph"`
+	Time     int64          `json:"ts"`
+	Duration int64          `json:"dur"`
+	Process  int            `json:"pid"`
+	Thread   int            `json:"tid"`
+	Args     map[string]any `json:"args,omitempty"`
+}
+
+// WriteChromeTrace exports the spans in the Chrome trace event format, which can be
+// loaded in chrome://tracing or Perfetto. Each root span is shown on its own track.
+func (r *StartupReport) WriteChromeTrace(w io.Writer) error {
+	events := make([]chromeTraceEvent, 0)
+
+	var origin time.Time
+
+	for _, span := range r.Spans {
+		if origin.IsZero() || span.Start.Before(origin) {
+			origin = span.Start
+		}
+	}
+
+	var appendEvents func(spans []*Span, thread int)
+	appendEvents = func(spans []*Span, thread int) {
+		for _, span := range spans {
+			events = append(events, chromeTraceEvent{
+				Name:     span.Name,
+				Category: span.Kind,
+				Phase:    "X",
+				Time:     span.Start.Sub(origin).Microseconds(),
+				Duration: span.Duration.Microseconds(),
+				Process:  1,
+				Thread:   thread,
+				Args:     map[string]any{"self_us": span.Self().Microseconds()},
+			})
+
+			appendEvents(span.Children, thread)
+		}
+	}
+
+	for i, span := range r.Spans {
+		appendEvents([]*Span{span}, i+1)
+	}
+
+	return json.NewEncoder(w).Encode(map[string]any{"traceEvents": events})
+}
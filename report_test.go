@@ -0,0 +1,91 @@
+package di
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+type SlowThing struct {
+	Alt *Thing1Alt
+}
+
+func (s *SlowThing) Initialize() {
+	time.Sleep(2 * time.Millisecond)
+}
+
+func TestStartupReport(t *testing.T) {
+	resetContainer()
+	EnableStartupReport()
+
+	BindInstance(&Thing1{})
+	BindProvider(func(thing1 *Thing1) (*Thing1Alt, error) {
+		time.Sleep(5 * time.Millisecond)
+		return &Thing1Alt{}, nil
+	})
+	BindAuto[SlowThing]()
+	Instance[SlowThing]()
+
+	report := GetStartupReport()
+
+	if len(report.Spans) != 1 {
+		t.Fatal("Expected a single root span", report.Spans)
+	}
+
+	root := report.Spans[0]
+
+	if root.Kind != SpanBuild || root.Name != "di.SlowThing" || len(root.Children) != 2 {
+		t.Fatal("Failed asserting root span", root)
+	}
+
+	callback := root.Children[0]
+	initialize := root.Children[1]
+
+	if callback.Kind != SpanCallback || callback.Name != "*di.Thing1Alt" || callback.Duration < 5*time.Millisecond {
+		t.Error("Failed asserting callback span", callback)
+	}
+
+	if initialize.Kind != SpanInitialize || initialize.Duration < 2*time.Millisecond {
+		t.Error("Failed asserting initialize span", initialize)
+	}
+
+	if root.Duration < callback.Duration+initialize.Duration || root.Self() != root.Duration-callback.Duration-initialize.Duration {
+		t.Error("Failed asserting inclusive and self durations", root)
+	}
+
+	var tree bytes.Buffer
+	report.WriteTree(&tree)
+	lines := strings.Split(strings.TrimSpace(tree.String()), "\n")
+
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "build di.SlowThing") || !strings.HasPrefix(lines[1], "  callback *di.Thing1Alt") {
+		t.Error("Failed asserting tree, children should be sorted by duration", tree.String())
+	}
+
+	var trace bytes.Buffer
+	report.WriteChromeTrace(&trace)
+
+	var decoded struct {
+		TraceEvents []map[string]any `json:"traceEvents"`
+	}
+
+	if err := json.Unmarshal(trace.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(decoded.TraceEvents) != 3 || decoded.TraceEvents[0]["ph"] != "X" || decoded.TraceEvents[0]["name"] != "di.SlowThing" {
+		t.Error("Failed asserting chrome trace", trace.String())
+	}
+}
+
+func TestStartupReportDisabled(t *testing.T) {
+	resetContainer()
+
+	BindAuto[Thing1]()
+	Instance[Thing1]()
+
+	if len(GetStartupReport().Spans) != 0 {
+		t.Error("Nothing should be recorded unless enabled")
+	}
+}
@@ -1,15 +1,15 @@
 package config
 
 type AppConfig struct {
-	VarA string
-	VarB string
+	VarA string `env:"APP_VAR_A,default=This will be sent as an argument to the query from ServiceA"`
+	VarB string `env:"APP_VAR_B,default=This will be sent as an argument to the query from ServiceB"`
 }
 
 type DBConfig struct {
-	Driver   string
-	Host     string
-	Port     string
-	Username string
-	Password string
-	DBName   string
+	Driver   string `env:"DB_DRIVER,default=driver"`
+	Host     string `env:"DB_HOST,default=host"`
+	Port     string `env:"DB_PORT,default=123"`
+	Username string `env:"DB_USERNAME,default=username"`
+	Password string `env:"DB_PASSWORD,default=password" di:"secret"`
+	DBName   string `env:"DB_NAME,default=dbname"`
 }
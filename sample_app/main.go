@@ -15,21 +15,11 @@ func main() {
 	logger := log.New(os.Stdout, "DI: ", 0)
 	di.SetLogger(logger)
 
-	// This would usually involve reading from environment variable instead of hard-coded strings.
+	// Configuration is read from environment variables, falling back to the
+	// defaults set in the `env` tags of the config structs.
 
-	di.BindInstance(&config.AppConfig{
-		VarA: "This will be sent as an argument to the query from ServiceA",
-		VarB: "This will be sent as an argument to the query from ServiceB",
-	})
-
-	di.BindInstance(&config.DBConfig{
-		Driver:   "driver",
-		Host:     "host",
-		Port:     "123",
-		Username: "username",
-		Password: "password",
-		DBName:   "dbname",
-	})
+	di.BindEnv[config.AppConfig]("")
+	di.BindEnv[config.DBConfig]("")
 
 	// Now we bind a provider that will use the DBConfig we bound to above.
 
@@ -0,0 +1,54 @@
+package di
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// Source is the location of the call that set a binding.
+type Source struct {
+	File string
+	Line int
+}
+
+func (s Source) String() string {
+	if s.File == "" {
+		return "unknown"
+	}
+
+	return fmt.Sprintf("%s:%d", s.File, s.Line)
+}
+
+var packageDir = func() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Dir(file)
+}()
+
+// callerSource returns the first caller outside of this package, tests excepted.
+func callerSource() Source {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+
+	for {
+		frame, more := frames.Next()
+
+		if filepath.Dir(frame.File) != packageDir || strings.HasSuffix(frame.File, "_test.go") {
+			return Source{File: frame.File, Line: frame.Line}
+		}
+
+		if !more {
+			return Source{}
+		}
+	}
+}
+
+// BindingSource returns where the binding of key was set.
+func (c *Container) BindingSource(key Id) (Source, bool) {
+	if b := c.getBinding(key); b != nil {
+		return b.source, true
+	}
+
+	return Source{}, false
+}
@@ -0,0 +1,44 @@
+package di
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestBindingSource(t *testing.T) {
+	resetContainer()
+
+	_, _, line, _ := runtime.Caller(0)
+	BindType[ModuleRepository, ModuleRepositoryImpl]()
+
+	source, ok := GetContainer().BindingSource(TypeId[ModuleRepository]())
+
+	if !ok || !strings.HasSuffix(source.File, "source_test.go") || source.Line != line+1 {
+		t.Error("Failed asserting binding source", source)
+	}
+
+	if source, _ := GetContainer().BindingSource(TypeId[ModuleRepositoryImpl]()); source.Line != line+1 {
+		t.Error("Failed asserting source of implicit binding", source)
+	}
+
+	if _, ok := GetContainer().BindingSource(TypeId[ModuleHandler]()); ok {
+		t.Error("Failed asserting missing binding")
+	}
+}
+
+func TestResolveErrorSource(t *testing.T) {
+	resetContainer()
+
+	_, _, line, _ := runtime.Caller(0)
+	BindConstructor(func(_ *ModuleStore) *ModuleHandler { return &ModuleHandler{} })
+
+	_, err := Resolve[ModuleHandler]()
+	site := fmt.Sprintf("source_test.go:%d", line+1)
+
+	if err == nil || !strings.Contains(err.Error(), "rule di.ModuleStore not found, required by di.ModuleHandler (bound at ") ||
+		strings.Count(err.Error(), site) != 2 {
+		t.Error("Failed asserting binding site of resolution error", err)
+	}
+}
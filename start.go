@@ -0,0 +1,309 @@
+package di
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"sort"
+)
+
+// SetStartConcurrency bounds the number of singletons built concurrently by Start().
+func (c *Container) SetStartConcurrency(workers int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.workers = workers
+}
+
+func (c *Container) eagerIds() []Id {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var ids []Id
+
+	for id, alternatives := range c.rules {
+		if b := c.selectBinding(alternatives); b != nil && b.eager {
+			ids = append(ids, id)
+		}
+	}
+
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+
+	return ids
+}
+
+// eagerDependencies walks the dependencies of id, through rules that are not eager,
+// and returns the eager ones it reaches.
+func (c *Container) eagerDependencies(id Id, eager map[Id]bool) []Id {
+	var found []Id
+
+	visited := map[Id]bool{id: true}
+	pending := c.ruleDependencies(c.GetRule(id))
+
+	for len(pending) > 0 {
+		dependency := pending[0]
+		pending = pending[1:]
+
+		if visited[dependency] {
+			continue
+		}
+
+		visited[dependency] = true
+
+		if eager[dependency] {
+			found = append(found, dependency)
+			continue
+		}
+
+		pending = append(pending, c.ruleDependencies(c.GetRule(dependency))...)
+	}
+
+	return found
+}
+
+type startResult struct {
+	id  Id
+	err error
+}
+
+// Start builds every eager singleton. Independent singletons are built concurrently, while
+// a singleton is only built after the eager singletons it depends on. All errors are returned.
+func (c *Container) Start(ctx context.Context) error {
+	ids := c.eagerIds()
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	eager := make(map[Id]bool, len(ids))
+
+	for _, id := range ids {
+		eager[id] = true
+	}
+
+	remaining := make(map[Id]int, len(ids))
+	dependants := make(map[Id][]Id)
+
+	for _, id := range ids {
+		dependencies := c.eagerDependencies(id, eager)
+		remaining[id] = len(dependencies)
+
+		for _, dependency := range dependencies {
+			dependants[dependency] = append(dependants[dependency], id)
+		}
+	}
+
+	var ready []Id
+
+	for _, id := range ids {
+		if remaining[id] == 0 {
+			ready = append(ready, id)
+		}
+	}
+
+	c.mutex.Lock()
+	workers := c.workers
+	c.mutex.Unlock()
+
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	jobs := make(chan Id)
+	results := make(chan startResult)
+	defer close(jobs)
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			for id := range jobs {
+				_, err := c.resolve(id, ruleType(c.GetRule(id)))
+				results <- startResult{id, err}
+			}
+		}()
+	}
+
+	var errs []error
+
+	done := 0
+	inFlight := 0
+	blocked := make(map[Id]bool)
+	ctxDone := ctx.Done()
+
+	var finish func(id Id, failed bool)
+	finish = func(id Id, failed bool) {
+		done++
+
+		for _, dependant := range dependants[id] {
+			remaining[dependant]--
+			blocked[dependant] = blocked[dependant] || failed
+
+			if remaining[dependant] > 0 {
+				continue
+			}
+
+			if blocked[dependant] {
+				errs = append(errs, fmt.Errorf("could not start %s: a dependency failed", dependant))
+				finish(dependant, true)
+				continue
+			}
+
+			ready = append(ready, dependant)
+		}
+	}
+
+	for done < len(ids) {
+		var next chan Id
+		var nextId Id
+
+		if len(ready) > 0 && ctx.Err() == nil {
+			next = jobs
+			nextId = ready[0]
+		}
+
+		if next == nil && inFlight == 0 {
+			break
+		}
+
+		select {
+		case next <- nextId:
+			ready = ready[1:]
+			inFlight++
+		case result := <-results:
+			inFlight--
+
+			if result.err != nil {
+				errs = append(errs, fmt.Errorf("could not start %s: %w", result.id, result.err))
+			}
+
+			finish(result.id, result.err != nil)
+		case <-ctxDone:
+			// stop scheduling, but wait for the builds in flight
+			ctxDone = nil
+		}
+	}
+
+	if ctx.Err() != nil {
+		errs = append(errs, ctx.Err())
+	} else if done < len(ids) {
+		errs = append(errs, errors.New("could not start eager singletons with circular dependencies"))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c *Container) ruleDependencies(rule Rule) []Id {
+	var ids []Id
+
+	switch r := rule.(type) {
+	case *typeRule:
+		ids = append(ids, reflectTypeId(r.typeTo))
+	case *autoRule:
+		ids = c.structDependencies(r.typeTo)
+	case *providerRule:
+		ids = callbackDependencies(r.callback)
+	case *factoryRule:
+		ids = callbackDependencies(r.callback)
+	case *constructorRule:
+		ids = callbackDependencies(r.callback)
+	}
+
+	return ids
+}
+
+func (c *Container) structDependencies(typeInfo reflect.Type) []Id {
+	return append(c.fieldDependencies(typeInfo), injectMethodDependencies(typeInfo)...)
+}
+
+func (c *Container) fieldDependencies(typeInfo reflect.Type) []Id {
+	var ids []Id
+
+	if typeInfo.Kind() != reflect.Struct {
+		return nil
+	}
+
+	for i := 0; i < typeInfo.NumField(); i++ {
+		field := typeInfo.Field(i)
+		tag, err := c.shouldInject(field)
+
+		if err != nil || !tag.inject {
+			continue
+		}
+
+		// unexported fields are skipped by injection unless private injection is allowed
+		if !field.IsExported() && !(tag.private && c.isPrivateInjectionAllowed(typeInfo)) {
+			continue
+		}
+
+		if tag.value != "" {
+			ids = append(ids, ValueId(tag.value))
+			continue
+		}
+
+		ids = append(ids, reflectTypeId(typeInfo.Field(i).Type))
+	}
+
+	return ids
+}
+
+func injectMethodDependencies(typeInfo reflect.Type) []Id {
+	var ids []Id
+
+	if typeInfo.Kind() != reflect.Struct {
+		return nil
+	}
+
+	ptrType := reflect.PointerTo(typeInfo)
+
+	for i := 0; i < ptrType.NumMethod(); i++ {
+		if isInjectMethod(ptrType.Method(i).Name) {
+			// skip the receiver
+			ids = append(ids, callbackDependencies(ptrType.Method(i).Func.Interface())[1:]...)
+		}
+	}
+
+	return ids
+}
+
+func callbackDependencies(callback any) []Id {
+	funcType := reflect.TypeOf(callback)
+	numIn := funcType.NumIn()
+
+	if funcType.IsVariadic() {
+		numIn--
+	}
+
+	ids := make([]Id, numIn)
+
+	for i := 0; i < numIn; i++ {
+		ids[i] = reflectTypeId(funcType.In(i))
+	}
+
+	return ids
+}
+
+func ruleType(rule Rule) reflect.Type {
+	switch r := rule.(type) {
+	case *typeRule:
+		return r.typeTo
+	case *autoRule:
+		return r.typeTo
+	case *instanceRule:
+		return r.instance.Type()
+	case *valueRule:
+		return r.value.Type()
+	case *genericRule:
+		return r.template
+	case *providerRule:
+		return reflect.TypeOf(r.callback).Out(0)
+	case *factoryRule:
+		return reflect.TypeOf(r.callback).Out(0)
+	case *constructorRule:
+		return reflect.TypeOf(r.callback).Out(0)
+	}
+
+	return nil
+}
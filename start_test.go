@@ -0,0 +1,176 @@
+package di
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type EagerA struct {
+	B *EagerB
+}
+
+type EagerB struct {
+	Thing1 *Thing1
+}
+
+type CircularA struct {
+	B *CircularB
+}
+
+type CircularB struct {
+	A *CircularA
+}
+
+func TestStart(t *testing.T) {
+	resetContainer()
+
+	var mutex sync.Mutex
+	var built []string
+
+	record := func(name string) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		built = append(built, name)
+	}
+
+	BindProvider(func() (*Thing1, error) {
+		record("Thing1")
+		return &Thing1{}, nil
+	})
+	BindProvider(func(b *EagerB) (*EagerA, error) {
+		record("EagerA")
+		return &EagerA{B: b}, nil
+	}, Eager())
+	BindProvider(func(thing1 *Thing1) (*EagerB, error) {
+		record("EagerB")
+		return &EagerB{Thing1: thing1}, nil
+	}, Eager())
+	BindProvider(func() (*Thing1Alt, error) {
+		record("Thing1Alt")
+		return &Thing1Alt{}, nil
+	})
+
+	if err := Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Join(built, ",") != "Thing1,EagerB,EagerA" {
+		t.Error("Eager singletons should be built in dependency order", built)
+	}
+
+	Instance[EagerA]()
+
+	if len(built) != 3 {
+		t.Error("Eager singletons should only be built once", built)
+	}
+}
+
+func TestStartConcurrent(t *testing.T) {
+	resetContainer()
+	GetContainer().SetStartConcurrency(2)
+
+	var started sync.WaitGroup
+	started.Add(2)
+
+	waitForBoth := func() error {
+		started.Done()
+		done := make(chan struct{})
+
+		go func() {
+			started.Wait()
+			close(done)
+		}()
+
+		select {
+		case <-done:
+			return nil
+		case <-time.After(5 * time.Second):
+			return errors.New("not built concurrently")
+		}
+	}
+
+	BindProvider(func() (*Thing1, error) {
+		return &Thing1{}, waitForBoth()
+	}, Eager())
+	BindProvider(func() (*Thing1Alt, error) {
+		return &Thing1Alt{}, waitForBoth()
+	}, Eager())
+
+	if err := Start(context.Background()); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStartErrors(t *testing.T) {
+	resetContainer()
+
+	BindProvider(func() (*Thing1, error) {
+		return nil, errors.New("thing1 failed")
+	}, Eager())
+	BindProvider(func() (*Thing1Alt, error) {
+		return nil, errors.New("alt failed")
+	}, Eager())
+	BindAuto[EagerB](Eager())
+	BindAuto[EagerA](Eager())
+
+	err := Start(context.Background())
+
+	if err == nil {
+		t.Fatal("Expected errors")
+	}
+
+	for _, expected := range []string{"thing1 failed", "alt failed", "could not start di.EagerB", "could not start di.EagerA: a dependency failed"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Error("Missing error", expected, "in", err)
+		}
+	}
+}
+
+func TestStartCancelled(t *testing.T) {
+	resetContainer()
+
+	BindAuto[Thing1](Eager())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := Start(ctx); !errors.Is(err, context.Canceled) {
+		t.Error("Expected the context error", err)
+	}
+}
+
+func TestCircularDependency(t *testing.T) {
+	resetContainer()
+
+	BindAuto[CircularA]()
+	BindAuto[CircularB]()
+
+	_, err := Resolve[CircularA]()
+
+	if err == nil || !strings.Contains(err.Error(), "circular dependency: di.CircularA -> di.CircularB -> di.CircularA") {
+		t.Error("Expected circular dependency error", err)
+	}
+}
+
+func TestDependenciesPrivateFields(t *testing.T) {
+	resetContainer()
+
+	BindAuto[PrivateInjected]()
+
+	if dependencies := GetContainer().ruleDependencies(GetContainer().GetRule(TypeId[PrivateInjected]())); len(dependencies) != 0 {
+		t.Error("Private members should not be dependencies unless the package opts in", dependencies)
+	}
+
+	AllowPrivateInjection[PrivateInjected]()
+
+	dependencies := GetContainer().ruleDependencies(GetContainer().GetRule(TypeId[PrivateInjected]()))
+
+	if len(dependencies) != 1 || dependencies[0] != TypeId[Thing1]() {
+		t.Error("Failed listing the tagged private member", dependencies)
+	}
+}
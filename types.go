@@ -1,6 +1,10 @@
 package di
 
-import "reflect"
+import (
+	"reflect"
+	"regexp"
+	"strings"
+)
 
 type Id string
 
@@ -15,8 +19,54 @@ func Type[T any]() reflect.Type {
 }
 
 func TypeId[T any]() Id {
-	iType := Type[T]()
-	return Id(iType.String())
+	return typeKey(Type[T]())
+}
+
+func reflectTypeId(typeInfo reflect.Type) Id {
+	if typeInfo.Kind() == reflect.Pointer {
+		typeInfo = typeInfo.Elem()
+	}
+
+	return typeKey(typeInfo)
+}
+
+func typeKey(typeInfo reflect.Type) Id {
+	return Id(typeInfo.String())
+}
+
+// importPath matches the import path qualifying a package name, e.g. `github.com/org/`
+var importPath = regexp.MustCompile(`[\w.~-]+(/[\w.~-]+)*/`)
+
+// Short returns id for display, with the type arguments of generic instantiations qualified
+// like the type itself, e.g. `repo.Repository[model.User]` instead of
+// `repo.Repository[github.com/org/app/model.User]`. Unlike ids, short forms may collide.
+func (id Id) Short() string {
+	if !strings.Contains(string(id), "/") {
+		return string(id)
+	}
+
+	return importPath.ReplaceAllString(string(id), "")
+}
+
+// genericBase returns the id of a generic instantiation without its type arguments
+func genericBase(id Id) (Id, bool) {
+	bracket := strings.IndexByte(string(id), '[')
+
+	// slices and maps start with a bracket
+	if bracket <= 0 || strings.HasPrefix(string(id), "map[") {
+		return "", false
+	}
+
+	return id[:bracket], true
+}
+
+// GenericId is the id of the template bound by BindGeneric for base, e.g. `repo.Repository`.
+func GenericId(base Id) Id {
+	return Id("generic=" + base)
+}
+
+func ValueId(name string) Id {
+	return Id("value=" + name)
 }
 
 func ObjectTypeId(object any) Id {
@@ -26,5 +76,5 @@ func ObjectTypeId(object any) Id {
 		value = value.Elem()
 
 	}
-	return Id(value.Type().String())
+	return typeKey(value.Type())
 }
@@ -1,8 +1,12 @@
 package di
 
 import (
+	htmltemplate "html/template"
+	"net/http"
+	"net/url"
 	"reflect"
 	"testing"
+	"text/template"
 )
 
 func TestTypeId(t *testing.T) {
@@ -16,3 +20,26 @@ func TestType(t *testing.T) {
 		t.Error("Types should match")
 	}
 }
+
+type GenericPair[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+func TestGenericTypeId(t *testing.T) {
+	if id := TypeId[GenericPair[string, *http.Request]](); id != "di.GenericPair[string,*net/http.Request]" || id.Short() != "di.GenericPair[string,*http.Request]" {
+		t.Error("Failed asserting generic type id", id)
+	}
+
+	if id := TypeId[GenericPair[Thing1, []GenericPair[int, url.URL]]](); id.Short() != "di.GenericPair[di.Thing1,[]di.GenericPair[int,url.URL]]" {
+		t.Error("Failed asserting nested generic type id", id)
+	}
+
+	if TypeId[GenericPair[int, template.Template]]() == TypeId[GenericPair[int, htmltemplate.Template]]() {
+		t.Error("Type arguments of the same name from different packages should not collide")
+	}
+
+	if ObjectTypeId(&GenericPair[Thing1, int]{}) != TypeId[GenericPair[Thing1, int]]() {
+		t.Error("Generic type IDs should match")
+	}
+}
@@ -0,0 +1,224 @@
+package di
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"sort"
+)
+
+type unbindOptions struct {
+	close bool
+}
+
+type UnbindOption func(o *unbindOptions)
+
+// CloseReplaced closes the singleton built by the replaced binding, by running the cleanup
+// of its constructor or calling its Close method.
+func CloseReplaced() UnbindOption {
+	return func(o *unbindOptions) {
+		o.close = true
+	}
+}
+
+// Rebind replaces the current binding on purpose, like Unbind followed by a Bind.
+func Rebind(options ...UnbindOption) BindOption {
+	return bindOptionFunc(func(b *binding) {
+		b.rebind = &unbindOptions{}
+
+		for _, option := range options {
+			option(b.rebind)
+		}
+	})
+}
+
+// captureKey is a singleton captured by the value of another binding
+type captureKey struct {
+	dependant Id
+	id        Id
+}
+
+// recordCapture remembers which binding received the singleton of id. Type rules only
+// pass it on, so it is recorded for the binding that requested them.
+func (c *Container) recordCapture(id Id, b *binding) {
+	if c.frame == nil {
+		return
+	}
+
+	if target := c.targetBinding(b); target == nil || !isSingleton(target.rule) {
+		return
+	}
+
+	for frame := c.frame; frame != nil; frame = frame.parent {
+		if parent := c.getBinding(frame.id); parent != nil {
+			if _, isType := parent.rule.(*typeRule); isType {
+				continue
+			}
+		}
+
+		key := captureKey{frame.id, id}
+
+		if _, exists := c.captured.Load(key); !exists {
+			c.captured.Store(key, true)
+		}
+
+		return
+	}
+}
+
+// Dependants returns the bindings whose values received the singleton of key.
+func (c *Container) Dependants(key Id) []Id {
+	var ids []Id
+
+	c.captured.Range(func(k, _ any) bool {
+		if k.(captureKey).id == key {
+			ids = append(ids, k.(captureKey).dependant)
+		}
+
+		return true
+	})
+
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+
+	return ids
+}
+
+// Unbind removes the binding of key, and returns the bindings that still hold the
+// singleton it built.
+func (c *Container) Unbind(key Id, options ...UnbindOption) ([]Id, error) {
+	if c.IsFrozen() {
+		return nil, fmt.Errorf("can't unbind %s, the container is frozen", key)
+	}
+
+	o := unbindOptions{}
+
+	for _, option := range options {
+		option(&o)
+	}
+
+	c.mutex.Lock()
+
+	// Freeze may have run since the check above
+	if c.IsFrozen() {
+		c.mutex.Unlock()
+		return nil, fmt.Errorf("can't unbind %s, the container is frozen", key)
+	}
+
+	alternatives := c.rules[key]
+	delete(c.rules, key)
+	c.mutex.Unlock()
+
+	if len(alternatives) == 0 {
+		return nil, fmt.Errorf("rule %s not found", key)
+	}
+
+	var dependants []Id
+	var errs []error
+
+	for _, b := range alternatives {
+		c.log(LogLevelTrace, "unbinding", slog.String("id", string(key)), slog.String("source", b.source.String()))
+
+		held, err := c.release(key, b, o)
+		dependants = append(dependants, held...)
+		errs = append(errs, err)
+	}
+
+	return dependants, errors.Join(errs...)
+}
+
+// release forgets the singleton of a replaced binding, and closes it if asked to
+func (c *Container) release(key Id, b *binding, o unbindOptions) ([]Id, error) {
+	dependants := c.Dependants(key)
+
+	for _, dependant := range dependants {
+		c.captured.Delete(captureKey{dependant, key})
+	}
+
+	if len(dependants) > 0 {
+		c.log(LogLevelWarning, "replaced instance is still held",
+			slog.String("id", string(key)),
+			slog.Any("dependants", dependants),
+		)
+	}
+
+	instance, cleanup, built := builtInstance(b.rule)
+
+	if !built {
+		return dependants, nil
+	}
+
+	c.untrack(instance)
+
+	if !o.close {
+		return dependants, nil
+	}
+
+	c.log(LogLevelTrace, "closing replaced instance", slog.String("id", string(key)))
+
+	if cleanup != nil {
+		cleanup()
+		return dependants, nil
+	}
+
+	return dependants, closeInstance(instance)
+}
+
+// builtInstance returns the singleton built by rule, with the cleanup of its constructor
+func builtInstance(rule Rule) (reflect.Value, func(), bool) {
+	switch r := rule.(type) {
+	case *instanceRule:
+		return r.instance, nil, true
+	case *autoRule:
+		return r.lazy.built()
+	case *providerRule:
+		return r.lazy.built()
+	case *constructorRule:
+		return r.lazy.built()
+	}
+
+	return reflect.Value{}, nil, false
+}
+
+func closeInstance(instance reflect.Value) error {
+	if !instance.IsValid() || !instance.CanInterface() {
+		return nil
+	}
+
+	switch closer := instance.Interface().(type) {
+	case interface{ Close() error }:
+		return closer.Close()
+	case interface{ Close() }:
+		closer.Close()
+	}
+
+	return nil
+}
+
+func (c *Container) untrack(value reflect.Value) {
+	if value.Kind() == reflect.Interface && !value.IsNil() {
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Pointer || value.IsNil() {
+		return
+	}
+
+	key := builtKey{value.Type(), value.Pointer()}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if _, exists := c.builtSet.LoadAndDelete(key); !exists {
+		return
+	}
+
+	for i, built := range c.built {
+		if built.Type() == value.Type() && built.Pointer() == value.Pointer() {
+			c.built = append(c.built[:i:i], c.built[i+1:]...)
+			break
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package di
+
+import (
+	"bytes"
+	"log"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+type ClosableStore struct {
+	closed bool
+}
+
+func (s *ClosableStore) Close() error {
+	s.closed = true
+	return nil
+}
+
+type ClosableStoreUser struct {
+	Store *ClosableStore
+}
+
+func TestUnbind(t *testing.T) {
+	resetContainer()
+
+	BindAuto[ClosableStore]()
+	BindAuto[ClosableStoreUser]()
+
+	user := Instance[ClosableStoreUser]()
+
+	dependants, err := Unbind[ClosableStore](CloseReplaced())
+
+	if err != nil || !reflect.DeepEqual(dependants, []Id{TypeId[ClosableStoreUser]()}) {
+		t.Error("Failed asserting dependants", dependants, err)
+	}
+
+	if !user.Store.closed {
+		t.Error("Failed asserting replaced instance is closed")
+	}
+
+	if GetContainer().HasRule(TypeId[ClosableStore]()) || len(GetContainer().builtInstances()) != 1 {
+		t.Error("Failed asserting binding is removed")
+	}
+
+	if _, err := Unbind[ClosableStore](); err == nil || !strings.Contains(err.Error(), "rule di.ClosableStore not found") {
+		t.Error("Failed asserting missing binding error", err)
+	}
+}
+
+func TestUnbindThroughType(t *testing.T) {
+	resetContainer()
+
+	BindAuto[ModuleStore]()
+	BindType[ModuleRepository, ModuleRepositoryImpl]()
+	BindAuto[ModuleHandler]()
+
+	Instance[ModuleHandler]()
+
+	if dependants := GetContainer().Dependants(TypeId[ModuleRepositoryImpl]()); !reflect.DeepEqual(dependants, []Id{TypeId[ModuleHandler]()}) {
+		t.Error("Type rules should not capture instances", dependants)
+	}
+
+	if dependants := GetContainer().Dependants(TypeId[ModuleStore]()); !reflect.DeepEqual(dependants, []Id{TypeId[ModuleRepositoryImpl]()}) {
+		t.Error("Failed asserting dependants", dependants)
+	}
+
+	dependants, err := Unbind[ModuleRepository]()
+
+	if err != nil || !reflect.DeepEqual(dependants, []Id{TypeId[ModuleHandler]()}) {
+		t.Error("Failed asserting dependants", dependants, err)
+	}
+}
+
+func TestRebind(t *testing.T) {
+	resetContainer()
+	GetContainer().SetStrictBinding(true)
+
+	var buffer bytes.Buffer
+	GetContainer().SetLogger(log.New(&buffer, "", 0))
+
+	closed := false
+	BindConstructor(func() (*ClosableStore, func(), error) {
+		return &ClosableStore{}, func() { closed = true }, nil
+	})
+	BindAuto[ClosableStoreUser]()
+
+	old := Instance[ClosableStoreUser]().Store
+
+	BindAuto[ClosableStore](Rebind(CloseReplaced()))
+
+	if !closed || old.closed {
+		t.Error("Failed asserting constructor cleanup")
+	}
+
+	if Instance[ClosableStore]() == old {
+		t.Error("Failed asserting rebinding")
+	}
+
+	if !strings.Contains(buffer.String(), "WARNING: replaced instance is still held id=di.ClosableStore dependants=[di.ClosableStoreUser]") {
+		t.Error("Failed asserting dependants warning", buffer.String())
+	}
+
+	closed = false
+	Close()
+
+	if closed {
+		t.Error("Cleanup should only run once")
+	}
+}
+
+func TestUnbindConcurrent(t *testing.T) {
+	resetContainer()
+
+	var closed atomic.Int32
+	BindConstructor(func() (*ClosableStore, func(), error) {
+		return &ClosableStore{}, func() { closed.Add(1) }, nil
+	})
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		Resolve[ClosableStore]()
+	}()
+
+	if _, err := Unbind[ClosableStore](CloseReplaced()); err != nil {
+		t.Error(err)
+	}
+
+	<-done
+
+	if closed.Load() > 1 {
+		t.Error("Cleanup should only run once", closed.Load())
+	}
+}
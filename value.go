@@ -0,0 +1,156 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func convertValue(value reflect.Value, t reflect.Type) (reflect.Value, error) {
+	if !value.IsValid() {
+		return reflect.Value{}, fmt.Errorf("invalid value for %s", t)
+	}
+
+	if value.Type().AssignableTo(t) {
+		return value, nil
+	}
+
+	from := value.Kind()
+	to := t.Kind()
+
+	switch {
+	case from == reflect.Interface || from == reflect.Pointer:
+		if value.IsNil() {
+			return reflect.Value{}, fmt.Errorf("nil %s cannot be converted to %s", value.Type(), t)
+		}
+
+		return convertValue(value.Elem(), t)
+	case from == reflect.String && to != reflect.String:
+		return parseValue(value.String(), t)
+	case from == to && (from == reflect.String || from == reflect.Bool):
+		return value.Convert(t), nil
+	case isIntKind(from) && isIntKind(to):
+		if reflect.Zero(t).OverflowInt(value.Int()) {
+			return reflect.Value{}, fmt.Errorf("%d overflows %s", value.Int(), t)
+		}
+
+		return value.Convert(t), nil
+	case isUintKind(from) && isUintKind(to):
+		if reflect.Zero(t).OverflowUint(value.Uint()) {
+			return reflect.Value{}, fmt.Errorf("%d overflows %s", value.Uint(), t)
+		}
+
+		return value.Convert(t), nil
+	case isIntKind(from) && isUintKind(to):
+		if value.Int() < 0 || reflect.Zero(t).OverflowUint(uint64(value.Int())) {
+			return reflect.Value{}, fmt.Errorf("%d overflows %s", value.Int(), t)
+		}
+
+		return value.Convert(t), nil
+	case (isIntKind(from) || isUintKind(from) || isFloatKind(from)) && isFloatKind(to):
+		return value.Convert(t), nil
+	case (from == reflect.Slice || from == reflect.Array) && to == reflect.Slice:
+		slice := reflect.MakeSlice(t, value.Len(), value.Len())
+
+		for i := 0; i < value.Len(); i++ {
+			elem, err := convertValue(value.Index(i), t.Elem())
+
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("element #%d: %w", i, err)
+			}
+
+			slice.Index(i).Set(elem)
+		}
+
+		return slice, nil
+	}
+
+	return reflect.Value{}, fmt.Errorf("%s cannot be converted to %s", value.Type(), t)
+}
+
+func parseValue(value string, t reflect.Type) (reflect.Value, error) {
+	result := reflect.New(t).Elem()
+
+	if t == Type[time.Duration]() {
+		duration, err := time.ParseDuration(value)
+
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		result.SetInt(int64(duration))
+		return result, nil
+	}
+
+	switch kind := t.Kind(); {
+	case kind == reflect.String:
+		result.SetString(value)
+	case kind == reflect.Bool:
+		parsed, err := strconv.ParseBool(value)
+
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		result.SetBool(parsed)
+	case isIntKind(kind):
+		parsed, err := strconv.ParseInt(value, 0, t.Bits())
+
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		result.SetInt(parsed)
+	case isUintKind(kind):
+		parsed, err := strconv.ParseUint(value, 0, t.Bits())
+
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		result.SetUint(parsed)
+	case isFloatKind(kind):
+		parsed, err := strconv.ParseFloat(value, t.Bits())
+
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		result.SetFloat(parsed)
+	case kind == reflect.Slice:
+		if strings.TrimSpace(value) == "" {
+			return reflect.MakeSlice(t, 0, 0), nil
+		}
+
+		parts := strings.Split(value, ",")
+		result = reflect.MakeSlice(t, len(parts), len(parts))
+
+		for i, part := range parts {
+			elem, err := parseValue(strings.TrimSpace(part), t.Elem())
+
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("element #%d: %w", i, err)
+			}
+
+			result.Index(i).Set(elem)
+		}
+	default:
+		return reflect.Value{}, fmt.Errorf("cannot parse a value of type %s", t)
+	}
+
+	return result, nil
+}
+
+func isIntKind(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Int64
+}
+
+func isUintKind(kind reflect.Kind) bool {
+	return kind >= reflect.Uint && kind <= reflect.Uintptr
+}
+
+func isFloatKind(kind reflect.Kind) bool {
+	return kind == reflect.Float32 || kind == reflect.Float64
+}
@@ -0,0 +1,136 @@
+package di
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type ValueInjected struct {
+	Port     int           `inject:"value=http.port"`
+	Port64   int64         `inject:"value=http.port"`
+	Host     string        `inject:"value=http.host"`
+	Timeout  time.Duration `inject:"value=http.timeout"`
+	Parsed   time.Duration `inject:"value=http.timeout.string"`
+	Origins  []string      `inject:"value=http.origins"`
+	Ratio    float64       `inject:"value=http.port"`
+	Untagged int
+}
+
+type ValueMismatch struct {
+	Port []int `inject:"value=http.host"`
+}
+
+type ValueMissing struct {
+	Port int `inject:"value=missing"`
+}
+
+func TestInjectValue(t *testing.T) {
+	Reset()
+
+	BindValue("http.port", 8080)
+	BindValue("http.host", "localhost")
+	BindValue("http.timeout", 5*time.Second)
+	BindValue("http.timeout.string", "1m")
+	BindValue("http.origins", []string{"a", "b"})
+	BindAuto[ValueInjected]()
+
+	v, err := Resolve[ValueInjected]()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v.Port != 8080 || v.Port64 != 8080 || v.Ratio != 8080 {
+		t.Error("Failed injecting numeric values", v)
+	}
+
+	if v.Host != "localhost" || v.Timeout != 5*time.Second || v.Parsed != time.Minute {
+		t.Error("Failed injecting values", v)
+	}
+
+	if !reflect.DeepEqual(v.Origins, []string{"a", "b"}) {
+		t.Error("Failed injecting slice", v.Origins)
+	}
+
+	if v.Untagged != 0 {
+		t.Error("Untagged primitives should not be injected")
+	}
+
+	if Value[int]("http.port") != 8080 {
+		t.Error("Failed resolving value")
+	}
+}
+
+func TestBindValueNil(t *testing.T) {
+	Reset()
+
+	BindValue[error]("error", nil)
+	BindValue[any]("any", 8080)
+
+	if err, resolveErr := ResolveValue[error]("error"); err != nil || resolveErr != nil {
+		t.Error("Failed resolving nil value", err, resolveErr)
+	}
+
+	if Value[int]("any") != 8080 {
+		t.Error("Failed resolving interface value")
+	}
+
+	for _, info := range Bindings() {
+		if info.Id == ValueId("error") && info.Type != Type[error]() {
+			t.Error("Failed asserting type of nil value", info.Type)
+		}
+	}
+}
+
+func TestInjectValueFails(t *testing.T) {
+	Reset()
+
+	BindValue("http.host", "localhost")
+	BindAuto[ValueMismatch]()
+	BindAuto[ValueMissing]()
+
+	if _, err := Resolve[ValueMismatch](); err == nil {
+		t.Error("Mismatching types should fail")
+	}
+
+	if _, err := Resolve[ValueMissing](); err == nil {
+		t.Error("Missing values should fail")
+	}
+
+	if _, err := ResolveValue[int]("http.host"); err == nil {
+		t.Error("Mismatching types should fail")
+	}
+}
+
+func TestParseValue(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected any
+	}{
+		{"42", 42},
+		{"0x10", uint8(16)},
+		{"true", true},
+		{"1.5", 1.5},
+		{"1h30m", 90 * time.Minute},
+		{"1, 2,3", []int{1, 2, 3}},
+		{"", []string{}},
+	}
+
+	for _, test := range tests {
+		parsed, err := parseValue(test.value, reflect.TypeOf(test.expected))
+
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if !reflect.DeepEqual(parsed.Interface(), test.expected) {
+			t.Errorf("Parsing %q, expected %#v got %#v", test.value, test.expected, parsed.Interface())
+		}
+	}
+
+	if _, err := parseValue("300", Type[uint8]()); err == nil {
+		t.Error("Overflows should fail")
+	}
+}
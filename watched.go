@@ -0,0 +1,238 @@
+package di
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var DefaultWatchInterval = 2 * time.Second
+
+type fileSource interface {
+	Path() string
+}
+
+type Watched[T any] struct {
+	sources     []ConfigSource
+	value       atomic.Value
+	reloading   sync.Mutex
+	mutex       sync.Mutex
+	subscribers map[int]func(old *T, new *T)
+	nextId      int
+	onError     func(error)
+	modTimes    map[string]time.Time
+	stop        chan struct{}
+}
+
+func NewWatched[T any](sources ...ConfigSource) (*Watched[T], error) {
+	w := &Watched[T]{
+		sources:     sources,
+		subscribers: make(map[int]func(old *T, new *T)),
+	}
+
+	if err := w.Reload(); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// Get returns the current snapshot, which must not be modified.
+func (w *Watched[T]) Get() *T {
+	return w.value.Load().(*T)
+}
+
+func (w *Watched[T]) Reload() error {
+	w.reloading.Lock()
+	defer w.reloading.Unlock()
+
+	config, err := LoadConfig[T](w.sources...)
+
+	if err != nil {
+		return err
+	}
+
+	var old *T
+
+	if current := w.value.Load(); current != nil {
+		old = current.(*T)
+	}
+
+	w.value.Store(config)
+
+	if old == nil || reflect.DeepEqual(old, config) {
+		return nil
+	}
+
+	w.mutex.Lock()
+	subscribers := make([]func(old *T, new *T), 0, len(w.subscribers))
+
+	for id := 0; id < w.nextId; id++ {
+		if subscriber, exists := w.subscribers[id]; exists {
+			subscribers = append(subscribers, subscriber)
+		}
+	}
+
+	w.mutex.Unlock()
+
+	for _, subscriber := range subscribers {
+		subscriber(old, config)
+	}
+
+	return nil
+}
+
+func (w *Watched[T]) Subscribe(subscriber func(old *T, new *T)) (unsubscribe func()) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	id := w.nextId
+	w.nextId++
+	w.subscribers[id] = subscriber
+
+	return func() {
+		w.mutex.Lock()
+		defer w.mutex.Unlock()
+
+		delete(w.subscribers, id)
+	}
+}
+
+func (w *Watched[T]) OnError(callback func(error)) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.onError = callback
+}
+
+// Watch reloads the value when SIGHUP is received or when one of its files changes,
+// checking every interval.
+func (w *Watched[T]) Watch(interval time.Duration) {
+	w.mutex.Lock()
+
+	if w.stop != nil {
+		w.mutex.Unlock()
+		return
+	}
+
+	stop := make(chan struct{})
+	w.stop = stop
+	w.modTimes = w.fileModTimes()
+	w.mutex.Unlock()
+
+	signals := make(chan os.Signal, 1)
+	notifyReload(signals)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		defer stopReload(signals)
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-signals:
+				w.reload()
+			case <-ticker.C:
+				if w.filesChanged() {
+					w.reload()
+				}
+			}
+		}
+	}()
+}
+
+func (w *Watched[T]) Close() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.stop != nil {
+		close(w.stop)
+		w.stop = nil
+	}
+}
+
+func (w *Watched[T]) reload() {
+	if err := w.Reload(); err != nil {
+		w.mutex.Lock()
+		onError := w.onError
+		w.mutex.Unlock()
+
+		if onError != nil {
+			onError(err)
+		}
+	}
+}
+
+func (w *Watched[T]) fileModTimes() map[string]time.Time {
+	modTimes := make(map[string]time.Time)
+
+	for _, source := range w.sources {
+		if file, ok := source.(fileSource); ok {
+			if info, err := os.Stat(file.Path()); err == nil {
+				modTimes[file.Path()] = info.ModTime()
+			}
+		}
+	}
+
+	return modTimes
+}
+
+func (w *Watched[T]) filesChanged() bool {
+	modTimes := w.fileModTimes()
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	changed := !reflect.DeepEqual(modTimes, w.modTimes)
+	w.modTimes = modTimes
+
+	return changed
+}
+
+// BindWatched binds Watched[T] for a live view of the config, and T for a snapshot
+// taken when the dependant is built.
+func BindWatched[T any](sources []ConfigSource, options ...BindOption) {
+	if Type[T]().Kind() != reflect.Struct {
+		panic(fmt.Sprintf("%s must be a struct", Type[T]()))
+	}
+
+	binder := binderOf(options)
+	c := binder.Container()
+
+	binder.Bind(
+		TypeId[Watched[T]](),
+		&providerRule{factoryRule: factoryRule{func() (*Watched[T], error) {
+			w, err := NewWatched[T](sources...)
+
+			if err != nil {
+				return nil, err
+			}
+
+			w.OnError(func(err error) {
+				c.log(LogLevelError, "could not reload",
+					slog.String("type", Type[T]().String()),
+					slog.Any("error", err),
+				)
+			})
+			w.Watch(DefaultWatchInterval)
+			c.addCleanup(w.Close)
+
+			return w, nil
+		}}},
+		options...,
+	)
+
+	binder.Bind(
+		TypeId[T](),
+		&factoryRule{func(w *Watched[T]) (*T, error) {
+			return w.Get(), nil
+		}},
+		options...,
+	)
+}
@@ -0,0 +1,10 @@
+//go:build js || wasip1
+
+package di
+
+import "os"
+
+// notifyReload does nothing, as there is no SIGHUP on this platform
+func notifyReload(_ chan<- os.Signal) {}
+
+func stopReload(_ chan<- os.Signal) {}
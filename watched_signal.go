@@ -0,0 +1,18 @@
+//go:build !js && !wasip1
+
+package di
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// notifyReload relays SIGHUP to signals
+func notifyReload(signals chan<- os.Signal) {
+	signal.Notify(signals, syscall.SIGHUP)
+}
+
+func stopReload(signals chan<- os.Signal) {
+	signal.Stop(signals)
+}
@@ -0,0 +1,105 @@
+package di
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+type WatchedConsumer struct {
+	Live     *Watched[FileDB]
+	Snapshot *FileDB
+}
+
+func TestWatched(t *testing.T) {
+	path := writeFile(t, "config.json", `{"host": "first", "password": "secret"}`)
+
+	w, err := NewWatched[FileDB](JSONFile(path))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var notified []string
+
+	unsubscribe := w.Subscribe(func(old *FileDB, new *FileDB) {
+		notified = append(notified, old.Host+" -> "+new.Host)
+	})
+
+	first := w.Get()
+	os.WriteFile(path, []byte(`{"host": "second", "password": "secret"}`), 0600)
+
+	if err := w.Reload(); err != nil {
+		t.Fatal(err)
+	}
+
+	if first.Host != "first" || w.Get().Host != "second" {
+		t.Error("Reload should swap the value without modifying the previous snapshot")
+	}
+
+	os.WriteFile(path, []byte(`{"host": "third"}`), 0600)
+
+	if err := w.Reload(); err == nil || w.Get().Host != "second" {
+		t.Error("Failed reloads should keep the current value")
+	}
+
+	unsubscribe()
+	os.WriteFile(path, []byte(`{"host": "fourth", "password": "secret"}`), 0600)
+	w.Reload()
+
+	if len(notified) != 1 || notified[0] != "first -> second" {
+		t.Error("Subscribers should be notified of changes until they unsubscribe", notified)
+	}
+}
+
+func TestWatchedFileChange(t *testing.T) {
+	path := writeFile(t, "config.json", `{"host": "first", "password": "secret"}`)
+
+	w, err := NewWatched[FileDB](JSONFile(path))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	changed := make(chan string, 1)
+	w.Subscribe(func(_ *FileDB, new *FileDB) {
+		changed <- new.Host
+	})
+
+	w.Watch(10 * time.Millisecond)
+	defer w.Close()
+
+	os.WriteFile(path, []byte(`{"host": "second", "password": "secret"}`), 0600)
+	os.Chtimes(path, time.Now().Add(time.Minute), time.Now().Add(time.Minute))
+
+	select {
+	case host := <-changed:
+		if host != "second" {
+			t.Error("Failed reloading changed file", host)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("File change was not detected")
+	}
+}
+
+func TestBindWatched(t *testing.T) {
+	Reset()
+	defer Close()
+
+	path := writeFile(t, "config.json", `{"host": "first", "password": "secret"}`)
+
+	BindWatched[FileDB]([]ConfigSource{JSONFile(path)})
+	BindAuto[WatchedConsumer]()
+
+	consumer := Instance[WatchedConsumer]()
+	os.WriteFile(path, []byte(`{"host": "second", "password": "secret"}`), 0600)
+	consumer.Live.Reload()
+
+	if consumer.Snapshot.Host != "first" || consumer.Live.Get().Host != "second" {
+		t.Error("Snapshots should keep their value while the live view changes")
+	}
+
+	if Instance[FileDB]().Host != "second" {
+		t.Error("New snapshots should have the reloaded value")
+	}
+}